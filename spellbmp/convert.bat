@@ -1 +0,0 @@
-go run main.go c:/src/xackery.com/static/tpe/raw/spellicons/ c:/src/xackery.com/static/tpe/spell/
\ No newline at end of file
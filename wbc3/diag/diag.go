@@ -0,0 +1,159 @@
+// Package diag writes problems found in game data as json or SARIF, for review tools to show them inline
+package diag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// Severity is how bad a problem is, using the SARIF level names
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Rule is a kind of problem, such as unknown-spell
+type Rule struct {
+	ID       string   `json:"id"`
+	Summary  string   `json:"summary"`
+	Severity Severity `json:"severity"`
+}
+
+// Diagnostic is a single problem in a file, Line and Column start at 1 and are 0 when unknown
+type Diagnostic struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	ItemID   string   `json:"itemId,omitempty"`
+	Field    string   `json:"field,omitempty"`
+}
+
+func (d Diagnostic) String() string {
+	where := d.File
+	if d.Line > 0 {
+		where = fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", where, d.Severity, d.Message, d.Rule)
+}
+
+// WriteJSON writes diagnostics as an indented json array
+func WriteJSON(w io.Writer, diagnostics []Diagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(diagnostics)
+}
+
+// sarif is the subset of SARIF 2.1.0 written by WriteSARIF
+type sarif struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level Severity `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  *int              `json:"ruleIndex,omitempty"`
+	Level      Severity          `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *sarifRegion `json:"region,omitempty"`
+	} `json:"physicalLocation"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteSARIF writes diagnostics as a SARIF 2.1.0 log of a single run of tool, rules describes every rule id used
+func WriteSARIF(w io.Writer, tool string, rules []Rule, diagnostics []Diagnostic) error {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: tool, Rules: []sarifRule{}}}, Results: []sarifResult{}}
+	index := make(map[string]int)
+	for i, rule := range rules {
+		r := sarifRule{ID: rule.ID, ShortDescription: sarifMessage{Text: rule.Summary}}
+		r.DefaultConfiguration.Level = rule.Severity
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, r)
+		index[rule.ID] = i
+	}
+
+	for _, d := range diagnostics {
+		result := sarifResult{RuleID: d.Rule, Level: d.Severity, Message: sarifMessage{Text: d.Message}}
+		i, ok := index[d.Rule]
+		if ok {
+			result.RuleIndex = &i
+		}
+
+		location := sarifLocation{}
+		location.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(d.File)
+		if d.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+		}
+		result.Locations = []sarifLocation{location}
+
+		if d.ItemID != "" || d.Field != "" {
+			result.Properties = make(map[string]string)
+			if d.ItemID != "" {
+				result.Properties["itemId"] = d.ItemID
+			}
+			if d.Field != "" {
+				result.Properties["field"] = d.Field
+			}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarif{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
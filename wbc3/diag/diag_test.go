@@ -0,0 +1,159 @@
+package diag
+
+import (
+	"bytes"
+	"testing"
+)
+
+var testRules = []Rule{
+	{ID: "unknown-spell", Summary: "Cast Spell power has no spell name", Severity: SeverityError},
+	{ID: "unknown-pickup", Summary: "Pickup sound has no slot", Severity: SeverityWarning},
+}
+
+var testDiagnostics = []Diagnostic{
+	{Rule: "unknown-spell", Severity: SeverityError, Message: "item 7 Power[0].data: spell 99 <none>", File: "mod/item.xml", Line: 12, Column: 3, ItemID: "7", Field: "Power[0].data"},
+	{Rule: "unknown-pickup", Severity: SeverityWarning, Message: "item 8: no pickup sound", File: "item.xml", Line: 20, Column: 2, ItemID: "8"},
+	{Rule: "import-row", Severity: SeverityError, Message: "unknown item id 9", File: "balance.csv"},
+}
+
+const goldenSARIF = `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "wbc3",
+          "rules": [
+            {
+              "id": "unknown-spell",
+              "shortDescription": {
+                "text": "Cast Spell power has no spell name"
+              },
+              "defaultConfiguration": {
+                "level": "error"
+              }
+            },
+            {
+              "id": "unknown-pickup",
+              "shortDescription": {
+                "text": "Pickup sound has no slot"
+              },
+              "defaultConfiguration": {
+                "level": "warning"
+              }
+            }
+          ]
+        }
+      },
+      "results": [
+        {
+          "ruleId": "unknown-spell",
+          "ruleIndex": 0,
+          "level": "error",
+          "message": {
+            "text": "item 7 Power[0].data: spell 99 <none>"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "mod/item.xml"
+                },
+                "region": {
+                  "startLine": 12,
+                  "startColumn": 3
+                }
+              }
+            }
+          ],
+          "properties": {
+            "field": "Power[0].data",
+            "itemId": "7"
+          }
+        },
+        {
+          "ruleId": "unknown-pickup",
+          "ruleIndex": 1,
+          "level": "warning",
+          "message": {
+            "text": "item 8: no pickup sound"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "item.xml"
+                },
+                "region": {
+                  "startLine": 20,
+                  "startColumn": 2
+                }
+              }
+            }
+          ],
+          "properties": {
+            "itemId": "8"
+          }
+        },
+        {
+          "ruleId": "import-row",
+          "level": "error",
+          "message": {
+            "text": "unknown item id 9"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "balance.csv"
+                }
+              }
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}
+`
+
+func TestWriteSARIF(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := WriteSARIF(buf, "wbc3", testRules, testDiagnostics)
+	if err != nil {
+		t.Fatalf("WriteSARIF: %v", err)
+	}
+	if buf.String() != goldenSARIF {
+		t.Errorf("WriteSARIF =\n%s\nwant\n%s", buf, goldenSARIF)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := WriteJSON(buf, testDiagnostics[1:2])
+	if err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	want := `[
+  {
+    "rule": "unknown-pickup",
+    "severity": "warning",
+    "message": "item 8: no pickup sound",
+    "file": "item.xml",
+    "line": 20,
+    "column": 2,
+    "itemId": "8"
+  }
+]
+`
+	if buf.String() != want {
+		t.Errorf("WriteJSON =\n%s\nwant\n%s", buf, want)
+	}
+
+	buf.Reset()
+	err = WriteJSON(buf, nil)
+	if err != nil || buf.String() != "[]\n" {
+		t.Errorf("WriteJSON(nil) = %q, %v, want []", buf, err)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xackery/wbc3-cli/wbc3/diag"
+	"github.com/xackery/wbc3-cli/wbc3/item"
+)
+
+// rules of problems outside item.Linter, next to the item.Check* ones
+const (
+	ruleUnmodeled = "unmodeled"
+	ruleImportRow = "import-row"
+	ruleRender    = "render"
+)
+
+// diagRules is every rule a diagnostic can carry
+var diagRules = []diag.Rule{
+	{ID: item.CheckMissingID, Summary: "Item has no id", Severity: diag.SeverityError},
+	{ID: item.CheckDuplicateID, Summary: "Item id is used by an earlier item", Severity: diag.SeverityError},
+	{ID: item.CheckMissingName, Summary: "Item has no name", Severity: diag.SeverityError},
+	{ID: item.CheckNotNumeric, Summary: "Numeric field is not a number, not a whole number where one is needed or out of range", Severity: diag.SeverityError},
+	{ID: item.CheckHeroSkill, Summary: "Hero Skill power is not in the hero skill table", Severity: diag.SeverityError},
+	{ID: item.CheckSpell, Summary: "Cast Spell power has no spell name in the string tables", Severity: diag.SeverityError},
+	{ID: item.CheckPickup, Summary: "Pickup sound is missing or has no slot", Severity: diag.SeverityWarning},
+	{ID: item.CheckPowerType, Summary: "Power type has no format and is rendered generically", Severity: diag.SeverityWarning},
+	{ID: item.CheckTooManyPowers, Summary: "Item has more powers than the game supports", Severity: diag.SeverityWarning},
+	{ID: item.CheckRarity, Summary: "Rarity is not a known rarity", Severity: diag.SeverityWarning},
+	{ID: item.CheckLevel, Summary: "Level is not a known level", Severity: diag.SeverityWarning},
+	{ID: ruleUnmodeled, Summary: "Attribute or element is not modeled by the tool", Severity: diag.SeverityNote},
+	{ID: ruleRender, Summary: "Power could not be rendered", Severity: diag.SeverityError},
+	{ID: ruleImportRow, Summary: "Csv row could not be imported", Severity: diag.SeverityError},
+}
+
+// diagnostics collects the problems found in one item.xml
+type diagnostics struct {
+	path string
+	doc  *item.Document
+	list []diag.Diagnostic
+}
+
+// add records a problem of the i-th item at the position of field, with the default severity of rule
+func (d *diagnostics) add(rule string, i int, itemID string, field string, message string) {
+	entry := diag.Diagnostic{
+		Rule:     rule,
+		Severity: ruleSeverity(rule),
+		Message:  message,
+		File:     d.path,
+		ItemID:   strings.TrimSpace(itemID),
+		Field:    field,
+	}
+	if d.doc != nil {
+		pos, ok := d.doc.FieldPosition(i, field)
+		if ok {
+			entry.Line, entry.Column = pos.Line, pos.Column
+		}
+	}
+	d.list = append(d.list, entry)
+}
+
+// addLine records a problem on a line of another file, such as a csv row
+func (d *diagnostics) addLine(rule string, path string, line int, message string) {
+	d.list = append(d.list, diag.Diagnostic{
+		Rule:     rule,
+		Severity: ruleSeverity(rule),
+		Message:  message,
+		File:     path,
+		Line:     line,
+		Column:   1,
+	})
+}
+
+// addUnmodeled records every unmodeled attribute and element, as errors when strict
+func (d *diagnostics) addUnmodeled(found []item.Unmodeled, strict bool) {
+	for _, u := range found {
+		d.add(ruleUnmodeled, u.Item, u.ItemID, u.Field(), u.String())
+		if strict {
+			d.list[len(d.list)-1].Severity = diag.SeverityError
+		}
+	}
+}
+
+// addProblems records every problem found by item.Linter
+func (d *diagnostics) addProblems(problems []item.Problem) {
+	for _, p := range problems {
+		d.add(p.Check, p.Item, p.ItemID, p.Field, p.Location()+": "+p.Message)
+	}
+}
+
+// addPowerErrors records the errors of item.Renderer.Resolve for the i-th item.
+// Unknown spells are skipped, item.Linter reports them as unknown-spell
+func (d *diagnostics) addPowerErrors(i int, itemID string, err error) {
+	itemID = strings.TrimSpace(itemID)
+	var powerErrs item.PowerErrors
+	if !errors.As(err, &powerErrs) {
+		d.add(ruleRender, i, itemID, "", fmt.Sprintf("item %s: %s", itemID, err))
+		return
+	}
+	for _, powerErr := range powerErrs {
+		if errors.Is(powerErr, item.ErrUnknownSpell) {
+			continue
+		}
+		d.add(ruleRender, i, itemID, fmt.Sprintf("Power[%d].data", powerErr.Power), fmt.Sprintf("item %s %s", itemID, powerErr))
+	}
+}
+
+// placeholderRules are the rules of fields and powers an export renders as a placeholder, the ones that fail it
+var placeholderRules = map[string]bool{item.CheckNotNumeric: true, item.CheckSpell: true, ruleRender: true}
+
+// summary prints every problem an export rendered a placeholder for, grouped by rule, and returns how many there are
+func (d *diagnostics) summary(w io.Writer) int {
+	groups := make(map[string][]diag.Diagnostic)
+	rules := []string{}
+	count := 0
+	for _, entry := range d.list {
+		if !placeholderRules[entry.Rule] {
+			continue
+		}
+		if len(groups[entry.Rule]) == 0 {
+			rules = append(rules, entry.Rule)
+		}
+		groups[entry.Rule] = append(groups[entry.Rule], entry)
+		count++
+	}
+
+	for _, rule := range rules {
+		fmt.Fprintf(w, "%s: %d\n", rule, len(groups[rule]))
+		for _, entry := range groups[rule] {
+			where := entry.File
+			if entry.Line > 0 {
+				where = fmt.Sprintf("%s:%d:%d", entry.File, entry.Line, entry.Column)
+			}
+			fmt.Fprintf(w, "  %s: %s\n", where, entry.Message)
+		}
+	}
+	return count
+}
+
+func ruleSeverity(id string) diag.Severity {
+	for _, rule := range diagRules {
+		if rule.ID == id {
+			return rule.Severity
+		}
+	}
+	return diag.SeverityError
+}
+
+// encodeDiagnostics encodes list as json or sarif
+func encodeDiagnostics(format string, list []diag.Diagnostic) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	var err error
+	switch format {
+	case "sarif":
+		err = diag.WriteSARIF(buf, "wbc3", diagRules, list)
+	default:
+		err = diag.WriteJSON(buf, list)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", format, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeDiagnostics writes list to path, as sarif when its extension is .sarif and json otherwise
+func writeDiagnostics(path string, list []diag.Diagnostic) error {
+	format := "json"
+	if strings.EqualFold(filepath.Ext(path), ".sarif") {
+		format = "sarif"
+	}
+	data, err := encodeDiagnostics(format, list)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data)
+}
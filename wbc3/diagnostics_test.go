@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xackery/wbc3-cli/wbc3/item"
+)
+
+const diagnosticsSource = `<Items>
+	<Item id="1">
+		<Name>Sword</Name>
+		<Power type="Hero Skill" data="999" level="1"/>
+		<Sound pickup="Ring"/>
+	</Item>
+	<Item id="2">
+		<Name>Feather</Name>
+		<Data value="abc"/>
+		<Sound pickup="Feather"/>
+	</Item>
+</Items>`
+
+func TestDiagnosticsSARIF(t *testing.T) {
+	doc, err := item.ParseDocument(strings.NewReader(diagnosticsSource))
+	if err != nil {
+		t.Fatalf("ParseDocument: %v", err)
+	}
+	diags := &diagnostics{path: "item.xml", doc: doc}
+	diags.addProblems((&item.Linter{}).Lint(doc.Items))
+
+	data, err := encodeDiagnostics("sarif", diags.list)
+	if err != nil {
+		t.Fatalf("encodeDiagnostics: %v", err)
+	}
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						Region struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				Properties map[string]string `json:"properties"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	err = json.Unmarshal(data, &log)
+	if err != nil {
+		t.Fatalf("decode sarif: %v", err)
+	}
+
+	got := []string{}
+	for _, result := range log.Runs[0].Results {
+		region := result.Locations[0].PhysicalLocation.Region
+		got = append(got, fmt.Sprintf("%s %s %s %s %d:%d", result.RuleID, result.Level, result.Properties["itemId"], result.Properties["field"], region.StartLine, region.StartColumn))
+	}
+	want := []string{
+		"unknown-hero-skill error 1 Power[0].data 4:3",
+		"not-numeric error 2 Data.value 9:3",
+		"unknown-pickup warning 2 Sound[0].pickup 10:3",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("results =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+const gameFolder = "Warlords Battlecry The Protectors of Etheria"
+
+var libraryPathRe = regexp.MustCompile(`"path"\s+"([^"]+)"`)
+
+// findTextDir returns the first language folder holding a Spells.txt, or an error listing every path tried
+func findTextDir(textDir string, gameDir string, lang string) (string, error) {
+	if textDir != "" {
+		_, err := os.Stat(textDir)
+		if err != nil {
+			return "", fmt.Errorf("text dir %s: %w", textDir, err)
+		}
+		return textDir, nil
+	}
+
+	gameDirs := []string{gameDir}
+	if gameDir == "" {
+		gameDirs = gameDirCandidates()
+	}
+
+	tried := []string{}
+	for _, dir := range gameDirs {
+		path := filepath.Join(dir, lang, "Spells.txt")
+		tried = append(tried, path)
+		_, err := os.Stat(path)
+		if err == nil {
+			return filepath.Dir(path), nil
+		}
+	}
+
+	return "", fmt.Errorf("Spells.txt not found (set -game, -text, WBC3_GAME_DIR or WBC3_TEXT_DIR), tried:\n\t%s", strings.Join(tried, "\n\t"))
+}
+
+// gameDirCandidates lists where steam usually installs the game, including extra steam libraries
+func gameDirCandidates() []string {
+	steamRoots := []string{}
+	if runtime.GOOS == "windows" {
+		steamRoots = append(steamRoots,
+			"C:/Program Files (x86)/Steam",
+			"C:/Program Files/Steam",
+		)
+	}
+
+	home, err := os.UserHomeDir()
+	if err == nil {
+		steamRoots = append(steamRoots,
+			filepath.Join(home, ".steam", "steam"),
+			filepath.Join(home, ".steam", "root"),
+			filepath.Join(home, ".local", "share", "Steam"),
+			filepath.Join(home, ".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam"),
+			filepath.Join(home, "snap", "steam", "common", ".local", "share", "Steam"),
+		)
+	}
+
+	seen := make(map[string]bool)
+	dirs := []string{}
+	add := func(root string) {
+		dir := filepath.Join(root, "steamapps", "common", gameFolder)
+		if seen[dir] {
+			return
+		}
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+
+	for _, root := range steamRoots {
+		add(root)
+		for _, library := range steamLibraries(root) {
+			add(library)
+		}
+	}
+	return dirs
+}
+
+// steamLibraries reads libraryfolders.vdf for any additional library paths
+func steamLibraries(steamRoot string) []string {
+	data, err := os.ReadFile(filepath.Join(steamRoot, "steamapps", "libraryfolders.vdf"))
+	if err != nil {
+		return nil
+	}
+
+	libraries := []string{}
+	for _, match := range libraryPathRe.FindAllStringSubmatch(string(data), -1) {
+		libraries = append(libraries, strings.ReplaceAll(match[1], `\\`, `\`))
+	}
+	return libraries
+}
+
+func envOr(key string, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeTestFile creates path with its folders
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSteamLibraries(t *testing.T) {
+	tests := []struct {
+		name string
+		vdf  string
+		want []string
+	}{
+		{"none", "", nil},
+		{"libraries", "\"libraryfolders\"\n{\n\t\"0\"\n\t{\n\t\t\"path\"\t\t\"/home/a/.local/share/Steam\"\n\t}\n\t\"1\"\n\t{\n\t\t\"path\"\t\t\"/mnt/games/SteamLibrary\"\n\t}\n}\n", []string{"/home/a/.local/share/Steam", "/mnt/games/SteamLibrary"}},
+		{"escaped windows path", "\"libraryfolders\"\n{\n\t\"1\"\n\t{\n\t\t\"path\"\t\t\"D:\\\\SteamLibrary\"\n\t}\n}\n", []string{`D:\SteamLibrary`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if tt.vdf != "" {
+				writeTestFile(t, filepath.Join(root, "steamapps", "libraryfolders.vdf"), tt.vdf)
+			}
+			got := steamLibraries(root)
+			if tt.want == nil && len(got) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("steamLibraries = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameDirCandidates(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("steam roots of the home folder are only searched alike outside windows")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	steam := filepath.Join(home, ".local", "share", "Steam")
+	library := filepath.Join(home, "games")
+	writeTestFile(t, filepath.Join(steam, "steamapps", "libraryfolders.vdf"), `"libraryfolders" { "0" { "path" "`+steam+`" } "1" { "path" "`+library+`" } }`)
+
+	got := gameDirCandidates()
+	want := filepath.Join(library, "steamapps", "common", gameFolder)
+	found := 0
+	seen := make(map[string]bool)
+	for _, dir := range got {
+		if seen[dir] {
+			t.Errorf("%s listed twice", dir)
+		}
+		seen[dir] = true
+		if dir == want {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Errorf("gameDirCandidates = %q, want the library %s", got, want)
+	}
+	if !seen[filepath.Join(steam, "steamapps", "common", gameFolder)] {
+		t.Errorf("gameDirCandidates = %q, want the steam root", got)
+	}
+}
+
+func TestFindTextDir(t *testing.T) {
+	game := t.TempDir()
+	writeTestFile(t, filepath.Join(game, "German", "Spells.txt"), "[SPELL_NAME_1] Feuerball\n")
+
+	tests := []struct {
+		name    string
+		textDir string
+		gameDir string
+		lang    string
+		want    string
+		wantErr string
+	}{
+		{"text dir wins", filepath.Join(game, "German"), filepath.Join(game, "missing"), "English", filepath.Join(game, "German"), ""},
+		{"missing text dir", filepath.Join(game, "French"), "", "English", "", "text dir"},
+		{"game dir and lang", "", game, "German", filepath.Join(game, "German"), ""},
+		{"lang missing", "", game, "English", "", filepath.Join(game, "English", "Spells.txt")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findTextDir(tt.textDir, tt.gameDir, tt.lang)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("findTextDir = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
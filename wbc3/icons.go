@@ -10,42 +10,38 @@ import (
 	"golang.org/x/image/bmp"
 )
 
-func main() {
-	err := run()
+// runIconsConvert converts every bmp icon of a folder into a png in the output directory
+func runIconsConvert(g *globals, args []string) error {
+	fs := newFlagSet("icons convert", g)
+	fs.Usage = func() {
+		printUsage(fs, "wbc3 icons convert [flags] <inputdir>", nil)
+	}
+	err := parseFlags(fs, args)
 	if err != nil {
-		fmt.Println("Failed to run:", err)
-		os.Exit(1)
+		return err
 	}
-}
-
-func run() error {
-
-	if len(os.Args) < 3 {
-		fmt.Println("usage: spellbmp <inputdir> <outputdir>")
-		os.Exit(1)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return usageError("icons convert: expected exactly one input dir")
 	}
 
-	inputDir := os.Args[1]
-	outputDir := os.Args[2]
+	inputDir := fs.Arg(0)
+	outputDir := g.outDir
 
 	dir, err := os.Open(inputDir)
 	if err != nil {
-		return fmt.Errorf("open input dir: %w", err)
+		return inputError(fmt.Errorf("open input dir: %w", err))
 	}
+	defer dir.Close()
 
 	files, err := dir.Readdir(0)
 	if err != nil {
-		return fmt.Errorf("read input dir: %w", err)
-	}
-
-	err = os.RemoveAll(outputDir)
-	if err != nil {
-		return fmt.Errorf("remove output dir: %w", err)
+		return inputError(fmt.Errorf("read input dir: %w", err))
 	}
 
 	err = os.MkdirAll(outputDir, 0755)
 	if err != nil {
-		return fmt.Errorf("create output dir: %w", err)
+		return outputError(fmt.Errorf("create output dir: %w", err))
 	}
 
 	for _, file := range files {
@@ -75,24 +71,24 @@ func run() error {
 func convert(in string, out string) error {
 	r, err := os.Open(in)
 	if err != nil {
-		return fmt.Errorf("convert open: %w", err)
+		return inputError(fmt.Errorf("convert open: %w", err))
 	}
 	defer r.Close()
 
-	w, err := os.Create(out)
+	dec, err := bmp.Decode(r)
 	if err != nil {
-		return fmt.Errorf("convert create: %w", err)
+		return dataError(fmt.Errorf("convert decode: %w", err))
 	}
-	defer w.Close()
 
-	dec, err := bmp.Decode(r)
+	w, err := os.Create(out)
 	if err != nil {
-		return fmt.Errorf("convert decode: %w", err)
+		return outputError(fmt.Errorf("convert create: %w", err))
 	}
+	defer w.Close()
 
 	err = png.Encode(w, dec)
 	if err != nil {
-		return fmt.Errorf("convert encode: %w", err)
+		return outputError(fmt.Errorf("convert encode: %w", err))
 	}
 
 	return nil
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xackery/wbc3-cli/wbc3/item"
+)
+
+// runItemsImport applies a csv or tsv in the export layout onto item.xml and writes the result
+func runItemsImport(g *globals, args []string) error {
+	fs := newFlagSet("items import", g)
+	fs.Usage = func() {
+		printUsage(fs, "wbc3 items import [flags] <file.csv|file.tsv>", nil)
+	}
+	f := addItemFlags(fs)
+	f.addDiag(fs)
+	overwrite := fs.Bool("overwrite", false, "allow the written item.xml to replace the input")
+	dryRun := fs.Bool("dry-run", false, "report changes without writing")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return usageError("items import: expected exactly one csv or tsv file")
+	}
+	csvPath := fs.Arg(0)
+	outPath := filepath.Join(g.outDir, "item.xml")
+
+	if !*dryRun && !*overwrite && samePath(outPath, f.in) {
+		return usageError("items import: %s would overwrite the input, set -out or -overwrite", outPath)
+	}
+
+	r, err := os.Open(f.in)
+	if err != nil {
+		return inputError(err)
+	}
+	defer r.Close()
+
+	doc, err := item.ParseDocument(r)
+	if err != nil {
+		return dataError(fmt.Errorf("parse %s: %w", f.in, err))
+	}
+
+	diags := &diagnostics{path: f.in, doc: doc}
+	finish := func(err error) error {
+		if f.diag == "" {
+			return err
+		}
+		diagErr := writeDiagnostics(f.diag, diags.list)
+		if err != nil {
+			return err
+		}
+		return diagErr
+	}
+
+	unmodeled := item.FindUnmodeled(doc.Items)
+	diags.addUnmodeled(unmodeled, f.strict)
+	err = reportUnmodeled(doc, f.in, unmodeled, f.strict)
+	if err != nil {
+		return finish(err)
+	}
+
+	cr, err := os.Open(csvPath)
+	if err != nil {
+		return inputError(err)
+	}
+	defer cr.Close()
+
+	comma := ','
+	if strings.EqualFold(filepath.Ext(csvPath), ".tsv") {
+		comma = '\t'
+	}
+
+	changes, err := item.ImportCSV(doc.Items, cr, comma)
+	var rowErrs item.RowErrors
+	if errors.As(err, &rowErrs) {
+		for _, rowErr := range rowErrs {
+			diags.addLine(ruleImportRow, csvPath, rowErr.Row, rowErr.Err)
+		}
+		return finish(dataError(fmt.Errorf("import %s, nothing written:\n%w", csvPath, err)))
+	}
+	if err != nil {
+		return inputError(fmt.Errorf("import %s: %w", csvPath, err))
+	}
+
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+	fmt.Printf("%d changes\n", len(changes))
+	if *dryRun {
+		return finish(nil)
+	}
+
+	// untouched items and everything the item structs do not model are written back as read
+	data, err := doc.Bytes()
+	if err != nil {
+		return finish(outputError(err))
+	}
+	return finish(writeFile(outPath, data))
+}
+
+// samePath reports if a and b point to the same file
+func samePath(a string, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return absA == absB
+}
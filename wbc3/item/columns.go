@@ -0,0 +1,230 @@
+package item
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PowersField is the column field expanding to a column per power, or a single cell when powers are combined
+const PowersField = "powers"
+
+// Column is a column of the markdown and wiki tables, Field names the value and Header titles it (the field's own title when empty).
+// The Header of PowersField may hold %d for the power number, such as "Power %d"
+type Column struct {
+	Field  string `json:"field"`
+	Header string `json:"header,omitempty"`
+}
+
+// columnField is the default title and value of a column field
+type columnField struct {
+	header string
+	value  func(item Resolved) string
+}
+
+func firstSound(item Resolved) RecordSound {
+	if len(item.Sounds) == 0 {
+		return RecordSound{}
+	}
+	return item.Sounds[0]
+}
+
+// columnFields is every field a Column can show, PowersField is handled by columnCells
+var columnFields = map[string]columnField{
+	"id":          {"ID", func(i Resolved) string { return strconv.Itoa(i.ID) }},
+	"name":        {"Name", func(i Resolved) string { return i.Name }},
+	"description": {"Description", func(i Resolved) string { return i.Description }},
+	"slot":        {"Slot", func(i Resolved) string { return string(i.Slot) }},
+	"rarity":      {"Rarity", func(i Resolved) string { return string(i.Rarity) }},
+	"level":       {"Level", func(i Resolved) string { return string(i.Level) }},
+	"quality":     {"Rarity", func(i Resolved) string { return fmt.Sprintf("%s %s", i.Rarity, i.Level) }},
+	"value":       {"Value", func(i Resolved) string { return strconv.Itoa(i.Value) }},
+	"durability":  {"Durability", func(i Resolved) string { return strconv.Itoa(i.Durability) }},
+	"iconrow":     {"Icon Row", func(i Resolved) string { return strconv.Itoa(i.IconRow) }},
+	"iconcol":     {"Icon Col", func(i Resolved) string { return strconv.Itoa(i.IconCol) }},
+	"icon":        {"Icon", func(i Resolved) string { return fmt.Sprintf("%d,%d", i.IconRow, i.IconCol) }},
+	"str":         {"STR", func(i Resolved) string { return strconv.Itoa(i.Requirements.Str) }},
+	"int":         {"INT", func(i Resolved) string { return strconv.Itoa(i.Requirements.Int) }},
+	"dex":         {"DEX", func(i Resolved) string { return strconv.Itoa(i.Requirements.Dex) }},
+	"cha":         {"CHA", func(i Resolved) string { return strconv.Itoa(i.Requirements.Cha) }},
+	"req":         {"Req", func(i Resolved) string { return i.RequirementsText }},
+	"cursed":      {"Cursed", func(i Resolved) string { return i.CursedText }},
+	"pickup":      {"Pickup", func(i Resolved) string { return firstSound(i).Pickup }},
+	"damage":      {"Damage Sound", func(i Resolved) string { return firstSound(i).Damage }},
+	"skin":        {"Skin", func(i Resolved) string { return firstSound(i).Skin }},
+}
+
+// DefaultColumns is the column set of the markdown and wiki tables
+var DefaultColumns = []Column{{Field: "name"}, {Field: "slot"}, {Field: "quality"}, {Field: PowersField}, {Field: "req"}, {Field: "cursed"}}
+
+// ColumnFields returns every field a Column can show, sorted
+func ColumnFields() []string {
+	fields := []string{PowersField}
+	for field := range columnFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// columnHeaders returns the title of every cell of a row of columns with powers power columns
+func columnHeaders(columns []Column, combinePowers bool, powers int) []string {
+	headers := []string{}
+	for _, column := range columns {
+		if column.Field != PowersField {
+			header := column.Header
+			if header == "" {
+				header = columnFields[column.Field].header
+			}
+			headers = append(headers, header)
+			continue
+		}
+
+		if combinePowers {
+			header := column.Header
+			if header == "" {
+				header = "Powers"
+			}
+			headers = append(headers, header)
+			continue
+		}
+		// only a literal %d is replaced, the header is user input and not a format string
+		format := column.Header
+		if format == "" {
+			format = "P%d"
+		}
+		if !strings.Contains(format, "%d") {
+			format += " %d"
+		}
+		for i := 1; i <= powers; i++ {
+			headers = append(headers, strings.ReplaceAll(format, "%d", strconv.Itoa(i)))
+		}
+	}
+	return headers
+}
+
+// columnCells returns every cell of item in a row of columns with powers power columns,
+// cell formats a value of a field for the output, combined powers are joined with <br>
+func columnCells(columns []Column, combinePowers bool, item Resolved, powers int, cell func(field string, value string) string) []string {
+	cells := []string{}
+	for _, column := range columns {
+		if column.Field != PowersField {
+			field, ok := columnFields[column.Field]
+			value := ""
+			if ok {
+				value = field.value(item)
+			}
+			cells = append(cells, cell(column.Field, value))
+			continue
+		}
+
+		if combinePowers {
+			texts := []string{}
+			for _, power := range item.Powers {
+				texts = append(texts, cell(PowersField, power.Text))
+			}
+			cells = append(cells, strings.Join(texts, "<br>"))
+			continue
+		}
+		for i := 0; i < powers; i++ {
+			if len(item.Powers) <= i {
+				cells = append(cells, "")
+				continue
+			}
+			cells = append(cells, cell(PowersField, item.Powers[i].Text))
+		}
+	}
+	return cells
+}
+
+// checkColumns reports columns with an unknown field
+func checkColumns(columns []Column) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("no columns")
+	}
+	for _, column := range columns {
+		_, ok := columnFields[column.Field]
+		if !ok && column.Field != PowersField {
+			return fmt.Errorf("unknown column %q, use %s", column.Field, strings.Join(ColumnFields(), ", "))
+		}
+	}
+	return nil
+}
+
+// ParseColumns reads a comma separated column list, each a field optionally renamed with =, such as "name,value=Gold,powers"
+func ParseColumns(list string) ([]Column, error) {
+	columns := []Column{}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		field, header, _ := strings.Cut(entry, "=")
+		columns = append(columns, Column{Field: strings.ToLower(strings.TrimSpace(field)), Header: strings.TrimSpace(header)})
+	}
+	err := checkColumns(columns)
+	if err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// LoadColumns reads a column set from a .csv file with a field,header header or a .json array of {"field", "header"}
+func LoadColumns(path string) ([]Column, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	columns := []Column{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		columns, err = ParseColumnsCSV(r)
+		if err != nil {
+			return nil, err
+		}
+	case ".json":
+		err = json.NewDecoder(r).Decode(&columns)
+		if err != nil {
+			return nil, fmt.Errorf("decode json: %w", err)
+		}
+		for i := range columns {
+			columns[i].Field = strings.ToLower(strings.TrimSpace(columns[i].Field))
+		}
+	default:
+		return nil, fmt.Errorf("%s: unsupported column file, use .csv or .json", path)
+	}
+
+	err = checkColumns(columns)
+	if err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// ParseColumnsCSV reads a column set with a field,header header, header may be left empty
+func ParseColumnsCSV(r io.Reader) ([]Column, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 2
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	if len(records) == 0 || !strings.EqualFold(records[0][0], "field") || !strings.EqualFold(records[0][1], "header") {
+		return nil, fmt.Errorf("read csv: missing field,header header")
+	}
+
+	columns := []Column{}
+	for _, record := range records[1:] {
+		columns = append(columns, Column{Field: strings.ToLower(strings.TrimSpace(record[0])), Header: strings.TrimSpace(record[1])})
+	}
+	return columns, nil
+}
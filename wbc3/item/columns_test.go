@@ -0,0 +1,26 @@
+package item
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColumnHeaders(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{"", []string{"Name", "P1", "P2"}},
+		{"Power %d", []string{"Name", "Power 1", "Power 2"}},
+		{"Bonus", []string{"Name", "Bonus 1", "Bonus 2"}},
+		{"Bonus %", []string{"Name", "Bonus % 1", "Bonus % 2"}},
+		{"%s %d %%", []string{"Name", "%s 1 %%", "%s 2 %%"}},
+	}
+	for _, tt := range tests {
+		columns := []Column{{Field: "name"}, {Field: PowersField, Header: tt.header}}
+		got := columnHeaders(columns, false, 2)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("columnHeaders(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package item
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// csvColumns is every per item column of the csv layout, powers follow as p1_type, p1_data, ...
+var csvColumns = []string{"id", "name", "slot", "rarity", "level", "value", "durability", "iconrow", "iconcol", "str", "int", "dex", "cha", "cursed", "heavilycursed", "description"}
+
+// csvPowerColumns is every column written per power, prefixed with p1_, p2_, ...
+var csvPowerColumns = []string{"type", "data", "level", "chance", "text"}
+
+// CSVHeader returns the header of the csv layout with the given number of power columns
+func CSVHeader(powers int) []string {
+	header := append([]string{}, csvColumns...)
+	for i := 1; i <= powers; i++ {
+		for _, column := range csvPowerColumns {
+			header = append(header, fmt.Sprintf("p%d_%s", i, column))
+		}
+	}
+	return header
+}
+
+// WriteCSV writes one row per item, comma is ',' for csv or '\t' for tsv.
+// There are MaxPowers power columns, or more when an item has more powers, and they hold
+// the raw item.xml attributes so the file can be imported back
+func WriteCSV(w io.Writer, items []Resolved, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	powers := MostPowers(items)
+	if powers < MaxPowers {
+		powers = MaxPowers
+	}
+	err := cw.Write(CSVHeader(powers))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		// fields that are not a number keep their raw value, so an import does not replace them with 0
+		number := func(field string, value int) string {
+			raw, ok := item.Invalid[field]
+			if ok {
+				return raw
+			}
+			return strconv.Itoa(value)
+		}
+		row := []string{
+			number("id", item.ID),
+			item.Name,
+			string(item.Slot),
+			string(item.Rarity),
+			string(item.Level),
+			number("Data.value", item.Value),
+			number("Durability", item.Durability),
+			number("Image.iconrow", item.IconRow),
+			number("Image.iconcol", item.IconCol),
+			number("Req.str", item.Requirements.Str),
+			number("Req.int", item.Requirements.Int),
+			number("Req.dex", item.Requirements.Dex),
+			number("Req.cha", item.Requirements.Cha),
+			// the first Curse as written, as import writes these columns to it and not to every Curse
+			item.RawCurse.Data,
+			item.RawCurse.Heavilycursed,
+			item.Description,
+		}
+		for i := 0; i < powers; i++ {
+			if i >= len(item.Powers) {
+				row = append(row, make([]string, len(csvPowerColumns))...)
+				continue
+			}
+			power := item.Powers[i]
+			row = append(row, power.Raw.Type, power.Raw.Data, power.Raw.Level, power.Raw.Chance, power.Text)
+		}
+		err = cw.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,325 @@
+package item
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Document is an item.xml kept alongside its original bytes, so it can be written back without loss.
+// Items may be edited in place and new items appended to Items.Items; use Remove to delete one.
+// Untouched items are written byte for byte, edited ones only have their changed attributes and text rewritten,
+// and comments, unmodeled attributes, element order and whitespace are kept.
+// Attrs and Extra are read only, changes to them are not written back
+type Document struct {
+	Items *Items
+
+	src []byte
+	// spans and orig line up with the first len(spans) entries of Items.Items
+	spans   []span
+	orig    []Item
+	removed []span
+	// itemsEnd is the offset of the </Items> end tag
+	itemsEnd int
+	lines    []int
+}
+
+// span is the byte range of an element in the source
+type span struct {
+	start int
+	end   int
+}
+
+// Position is a line and column in the source, both starting at 1
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// ParseDocument reads an item.xml keeping its bytes for a lossless write back
+func ParseDocument(r io.Reader) (*Document, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	d := &Document{Items: &Items{}, src: src, itemsEnd: -1}
+	for i, c := range src {
+		if c == '\n' {
+			d.lines = append(d.lines, i)
+		}
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(src))
+	depth := 0
+	for {
+		start := int(dec.InputOffset())
+		token, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", d.Position(int(dec.InputOffset())), err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				if t.Name.Local != "Items" {
+					return nil, fmt.Errorf("decode %s: root element is %s, expected Items", d.Position(start), t.Name.Local)
+				}
+				d.Items.XMLName = t.Name
+				d.Items.Attrs = t.Attr
+				depth++
+				continue
+			}
+			if depth == 1 && t.Name.Local == "Item" {
+				item := Item{}
+				err = dec.DecodeElement(&item, &t)
+				if err != nil {
+					return nil, fmt.Errorf("decode item at %s: %w", d.Position(start), err)
+				}
+				d.Items.Items = append(d.Items.Items, item)
+				d.orig = append(d.orig, cloneItem(item))
+				d.spans = append(d.spans, span{start: start, end: int(dec.InputOffset())})
+				continue
+			}
+			// anything else under Items is kept in the source and reported by FindUnmodeled
+			extra := Element{}
+			err = dec.DecodeElement(&extra, &t)
+			if err != nil {
+				return nil, fmt.Errorf("decode %s: %w", d.Position(start), err)
+			}
+			d.Items.Extra = append(d.Items.Extra, extra)
+		case xml.EndElement:
+			depth--
+			if depth == 0 {
+				d.itemsEnd = start
+			}
+		}
+	}
+	if d.itemsEnd < 0 {
+		return nil, fmt.Errorf("decode: missing Items element")
+	}
+	return d, nil
+}
+
+// Position returns the line and column of a byte offset in the source
+func (d *Document) Position(offset int) Position {
+	line := sort.SearchInts(d.lines, offset)
+	lineStart := 0
+	if line > 0 {
+		lineStart = d.lines[line-1] + 1
+	}
+	return Position{Offset: offset, Line: line + 1, Column: offset - lineStart + 1}
+}
+
+// ItemPosition returns where the i-th item starts in the source, false for items added after parsing
+func (d *Document) ItemPosition(i int) (Position, bool) {
+	if i < 0 || i >= len(d.spans) {
+		return Position{}, false
+	}
+	return d.Position(d.spans[i].start), true
+}
+
+// FieldPosition returns where the element of a field of the i-th item starts, such as Power[1].data or Data.value,
+// falling back to the start of the item for its own attributes or elements it does not have
+func (d *Document) FieldPosition(i int, field string) (Position, bool) {
+	pos, ok := d.ItemPosition(i)
+	if !ok {
+		return pos, false
+	}
+	name, _, _ := strings.Cut(field, ".")
+	index := 0
+	if open := strings.Index(name, "["); open >= 0 && strings.HasSuffix(name, "]") {
+		n, err := strconv.Atoi(name[open+1 : len(name)-1])
+		if err != nil {
+			return pos, true
+		}
+		name, index = name[:open], n
+	}
+
+	s := d.spans[i]
+	_, children, err := scanItem(d.src[s.start:s.end])
+	if err != nil {
+		return pos, true
+	}
+	for _, c := range children {
+		if c.name != name {
+			continue
+		}
+		if index == 0 {
+			return d.Position(s.start + c.tag.start), true
+		}
+		index--
+	}
+	return pos, true
+}
+
+// Item returns the item with id, or nil
+func (d *Document) Item(id string) *Item {
+	for i := range d.Items.Items {
+		if strings.TrimSpace(d.Items.Items[i].ID) == id {
+			return &d.Items.Items[i]
+		}
+	}
+	return nil
+}
+
+// Remove deletes the item with id, reporting if it was found
+func (d *Document) Remove(id string) bool {
+	for i := range d.Items.Items {
+		if strings.TrimSpace(d.Items.Items[i].ID) != id {
+			continue
+		}
+		d.Items.Items = append(d.Items.Items[:i], d.Items.Items[i+1:]...)
+		if i < len(d.spans) {
+			d.removed = append(d.removed, d.spans[i])
+			d.spans = append(d.spans[:i], d.spans[i+1:]...)
+			d.orig = append(d.orig[:i], d.orig[i+1:]...)
+		}
+		return true
+	}
+	return false
+}
+
+// Bytes returns the document with every change applied
+func (d *Document) Bytes() ([]byte, error) {
+	type segment struct {
+		span
+		index   int
+		removed bool
+	}
+	segments := []segment{}
+	for i, s := range d.spans {
+		segments = append(segments, segment{span: s, index: i})
+	}
+	for _, s := range d.removed {
+		segments = append(segments, segment{span: s, removed: true})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].start < segments[j].start })
+
+	// new items go after the last original item, or inside an empty Items element
+	insertAt := len(bytes.TrimRight(d.src[:d.itemsEnd], " \t\r\n"))
+	if len(segments) > 0 {
+		insertAt = segments[len(segments)-1].end
+	}
+
+	out := &bytes.Buffer{}
+	cursor := 0
+	for _, s := range segments {
+		between := d.src[cursor:s.start]
+		if s.removed {
+			between = trimLine(between)
+		}
+		out.Write(between)
+		cursor = s.end
+		if s.removed {
+			continue
+		}
+
+		raw := d.src[s.start:s.end]
+		if reflect.DeepEqual(d.orig[s.index], d.Items.Items[s.index]) {
+			out.Write(raw)
+			continue
+		}
+		patched, err := patchItem(raw, d.orig[s.index], d.Items.Items[s.index])
+		if err != nil {
+			return nil, fmt.Errorf("item %s: %w", d.Items.Items[s.index].ID, err)
+		}
+		out.Write(patched)
+	}
+	out.Write(d.src[cursor:insertAt])
+	cursor = insertAt
+
+	indent := d.itemIndent()
+	for _, item := range d.Items.Items[len(d.spans):] {
+		data, err := marshalElement(item, "Item", indent)
+		if err != nil {
+			return nil, fmt.Errorf("item %s: %w", item.ID, err)
+		}
+		out.WriteString("\n" + indent)
+		out.Write(data)
+	}
+	out.Write(d.src[cursor:])
+	return out.Bytes(), nil
+}
+
+// WriteTo writes the document with every change applied
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	data, err := d.Bytes()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// itemIndent is the whitespace in front of the first item, or a tab
+func (d *Document) itemIndent() string {
+	if len(d.spans) == 0 {
+		return "\t"
+	}
+	return leadingIndent(d.src, d.spans[0].start, "\t")
+}
+
+// leadingIndent returns the spaces and tabs between the previous newline and offset
+func leadingIndent(src []byte, offset int, fallback string) string {
+	i := offset
+	for i > 0 && (src[i-1] == ' ' || src[i-1] == '\t') {
+		i--
+	}
+	if i > 0 && src[i-1] != '\n' {
+		return fallback
+	}
+	return string(src[i:offset])
+}
+
+// trimLine drops trailing spaces and tabs and the newline before them, so removing the element after it leaves no blank line
+func trimLine(b []byte) []byte {
+	trimmed := bytes.TrimRight(b, " \t")
+	trimmed = bytes.TrimSuffix(trimmed, []byte("\n"))
+	return bytes.TrimSuffix(trimmed, []byte("\r"))
+}
+
+// cloneItem copies an item so later edits of its slices do not leak into the copy
+func cloneItem(item Item) Item {
+	item.Power = append([]Power(nil), item.Power...)
+	item.Sound = append([]Sound(nil), item.Sound...)
+	item.Curse = append([]Curse(nil), item.Curse...)
+	item.Attrs = append([]xml.Attr(nil), item.Attrs...)
+	item.Extra = append([]Element(nil), item.Extra...)
+	return item
+}
+
+var emptyElementRe = regexp.MustCompile(`<(\w+)([^<>]*)></(\w+)>`)
+
+// marshalElement encodes v as an element called name, indented for nesting under indent, with empty elements self closed
+func marshalElement(v interface{}, name string, indent string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := xml.NewEncoder(buf)
+	enc.Indent(indent, "\t")
+	err := enc.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: name}})
+	if err != nil {
+		return nil, err
+	}
+	data := bytes.TrimPrefix(buf.Bytes(), []byte(indent))
+	return emptyElementRe.ReplaceAllFunc(data, func(m []byte) []byte {
+		sub := emptyElementRe.FindSubmatch(m)
+		if !bytes.Equal(sub[1], sub[3]) {
+			return m
+		}
+		return []byte("<" + string(sub[1]) + string(sub[2]) + "/>")
+	}), nil
+}
@@ -0,0 +1,155 @@
+package item
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const documentSource = `<?xml version="1.0" encoding="utf-8"?>
+<Items>
+	<!-- weapons -->
+	<Item id="1" mod="x">
+		<Name>Sword</Name>
+		<Power id="0" type="armor" data="2"/>
+		<Power id="1" type="speed" data="3" newattr="1"></Power>
+		<Data value="500" level="greater" rarity="Rare"/>
+		<Req str="10"/>
+	</Item>
+	<Item id="2">
+		<Name>Ring</Name>
+		<Power type="armor" data="1"/>
+		<Data value="50"/>
+		<Mystery>?</Mystery>
+	</Item>
+</Items>
+`
+
+func TestDocumentRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(d *Document)
+		want string
+	}{
+		{
+			name: "unchanged",
+			edit: func(d *Document) {},
+			want: documentSource,
+		},
+		{
+			name: "change attribute",
+			edit: func(d *Document) { d.Item("1").Data.Value = "600" },
+			want: strings.Replace(documentSource, `value="500"`, `value="600"`, 1),
+		},
+		{
+			name: "add attribute",
+			edit: func(d *Document) { d.Item("1").Req.Dex = "5" },
+			want: strings.Replace(documentSource, `<Req str="10"/>`, `<Req str="10" dex="5"/>`, 1),
+		},
+		{
+			name: "remove attribute",
+			edit: func(d *Document) { d.Item("1").Data.Level = "" },
+			want: strings.Replace(documentSource, ` level="greater"`, ``, 1),
+		},
+		{
+			name: "change text",
+			edit: func(d *Document) { d.Item("2").Name = "Ring & Band" },
+			want: strings.Replace(documentSource, `<Name>Ring</Name>`, `<Name>Ring &amp; Band</Name>`, 1),
+		},
+		{
+			name: "append power",
+			edit: func(d *Document) {
+				item := d.Item("2")
+				item.Power = append(item.Power, Power{Type: "speed", Data: "1"})
+			},
+			want: strings.Replace(documentSource, "\t\t<Power type=\"armor\" data=\"1\"/>\n",
+				"\t\t<Power type=\"armor\" data=\"1\"/>\n\t\t<Power type=\"speed\" data=\"1\"/>\n", 1),
+		},
+		{
+			name: "remove power",
+			edit: func(d *Document) {
+				item := d.Item("1")
+				item.Power = item.Power[:1]
+			},
+			want: strings.Replace(documentSource, "\t\t<Power id=\"1\" type=\"speed\" data=\"3\" newattr=\"1\"></Power>\n", "", 1),
+		},
+		{
+			name: "remove item",
+			edit: func(d *Document) {
+				if !d.Remove("2") {
+					t.Fatal("Remove(2) = false")
+				}
+			},
+			want: strings.Replace(documentSource, "\t<Item id=\"2\">\n\t\t<Name>Ring</Name>\n\t\t<Power type=\"armor\" data=\"1\"/>\n\t\t<Data value=\"50\"/>\n\t\t<Mystery>?</Mystery>\n\t</Item>\n", "", 1),
+		},
+		{
+			name: "append item",
+			edit: func(d *Document) {
+				d.Items.Items = append(d.Items.Items, Item{ID: "3", Name: "Axe", Power: []Power{{Type: "armor", Data: "4"}}})
+			},
+			want: strings.Replace(documentSource, "\t</Item>\n</Items>",
+				"\t</Item>\n\t<Item id=\"3\">\n\t\t<Name>Axe</Name>\n\t\t<Power type=\"armor\" data=\"4\"/>\n\t\t<Description/>\n\t\t<Image/>\n\t\t<Data/>\n\t\t<Durability/>\n\t\t<Req/>\n\t</Item>\n</Items>", 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseDocument(strings.NewReader(documentSource))
+			if err != nil {
+				t.Fatalf("ParseDocument: %v", err)
+			}
+			tt.edit(d)
+			got, err := d.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Bytes =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocumentUnmodeled(t *testing.T) {
+	d, err := ParseDocument(strings.NewReader(documentSource))
+	if err != nil {
+		t.Fatalf("ParseDocument: %v", err)
+	}
+	found := []string{}
+	for _, u := range FindUnmodeled(d.Items) {
+		pos, _ := d.FieldPosition(u.Item, u.Field())
+		found = append(found, pos.String()+" "+u.String())
+	}
+	want := []string{
+		`4:2 item 1: unmodeled attribute mod="x"`,
+		`7:3 item 1 Power[1]: unmodeled attribute newattr="1"`,
+		`15:3 item 2: unmodeled element <Mystery>`,
+	}
+	if !reflect.DeepEqual(found, want) {
+		t.Errorf("FindUnmodeled =\n%s\nwant\n%s", strings.Join(found, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDocumentFieldPosition(t *testing.T) {
+	d, err := ParseDocument(strings.NewReader(documentSource))
+	if err != nil {
+		t.Fatalf("ParseDocument: %v", err)
+	}
+	tests := []struct {
+		item  int
+		field string
+		want  string
+	}{
+		{0, "id", "4:2"},
+		{0, "Power[0].data", "6:3"},
+		{0, "Power[1].newattr", "7:3"},
+		{0, "Data.value", "8:3"},
+		{1, "Mystery", "15:3"},
+		{1, "Power[5].data", "11:2"},
+	}
+	for _, tt := range tests {
+		pos, ok := d.FieldPosition(tt.item, tt.field)
+		if !ok || pos.String() != tt.want {
+			t.Errorf("FieldPosition(%d, %q) = %s, %v, want %s", tt.item, tt.field, pos, ok, tt.want)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package item
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PowerFunc renders a power of one type
+type PowerFunc func(r *Renderer, power RecordPower) (string, error)
+
+// PowerFormats maps a power type to the func rendering it
+type PowerFormats struct {
+	// funcs and types are keyed by PowerType.Key
+	funcs map[string]PowerFunc
+	types map[string]PowerType
+}
+
+// NewPowerFormats returns formats without any power type
+func NewPowerFormats() *PowerFormats {
+	return &PowerFormats{funcs: make(map[string]PowerFunc), types: make(map[string]PowerType)}
+}
+
+// DefaultPowerFormats returns the formats of the power types the original item tool gave their own text:
+// Cast Spell, Hero Skill and Speed. The units of other types are not known, they are rendered as "+data Type"
+// and flagged until a format is registered or loaded with LoadPowerTexts
+func DefaultPowerFormats() *PowerFormats {
+	f := NewPowerFormats()
+	f.Register(PowerCastSpell, castSpell)
+	f.Register(PowerHeroSkill, heroSkill)
+	f.Register(PowerSpeed, Text("{+data} Movement Speed"))
+	return f
+}
+
+// Register sets the func of a power type, replacing the one it had. Types are matched ignoring case and spacing
+func (f *PowerFormats) Register(powerType PowerType, fn PowerFunc) {
+	f.funcs[powerType.Key()] = fn
+	f.types[powerType.Key()] = powerType
+}
+
+// Lookup returns the func of a power type
+func (f *PowerFormats) Lookup(powerType PowerType) (PowerFunc, bool) {
+	fn, ok := f.funcs[powerType.Key()]
+	return fn, ok
+}
+
+// Types returns every registered power type as registered, sorted
+func (f *PowerFormats) Types() []PowerType {
+	types := []PowerType{}
+	for _, t := range f.types {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// Merge registers a Text of every entry of texts, replacing types f already has
+func (f *PowerFormats) Merge(texts map[PowerType]string) error {
+	for powerType, text := range texts {
+		err := CheckText(text)
+		if err != nil {
+			return fmt.Errorf("%s: %w", powerType, err)
+		}
+		f.Register(powerType, Text(text))
+	}
+	return nil
+}
+
+var placeholderRe = regexp.MustCompile(`\{(\+?)(\w+)(?:\*([^:}]+))?(?::([^}]+))?\}`)
+
+// placeholders is every name a Text can use, true for the numeric ones
+var placeholders = map[string]bool{"data": true, "level": true, "chance": true, "spell": false, "skill": false, "type": false}
+
+// placeholderNumber returns how a numeric placeholder match of placeholderRe is formatted
+func placeholderNumber(sub []string) (Number, error) {
+	n := Number{Signed: sub[1] == "+", Decimals: -1}
+	if sub[3] != "" {
+		scale, err := strconv.ParseFloat(sub[3], 64)
+		if err != nil {
+			return n, fmt.Errorf("%s: scale %q is not a number", sub[0], sub[3])
+		}
+		n.Scale = scale
+	}
+	if sub[4] != "" {
+		decimals, err := strconv.Atoi(sub[4])
+		if err != nil || decimals < 0 {
+			return n, fmt.Errorf("%s: decimals %q is not a positive number", sub[0], sub[4])
+		}
+		n.Decimals = decimals
+	}
+	return n, nil
+}
+
+// CheckText reports placeholders of a Text that do not exist or have a bad number format
+func CheckText(text string) error {
+	for _, sub := range placeholderRe.FindAllStringSubmatch(text, -1) {
+		numeric, ok := placeholders[sub[2]]
+		if !ok {
+			return fmt.Errorf("unknown placeholder %s", sub[0])
+		}
+		if !numeric {
+			if sub[1] != "" || sub[3] != "" || sub[4] != "" {
+				return fmt.Errorf("%s: only numbers can be signed, scaled or rounded", sub[0])
+			}
+			continue
+		}
+		_, err := placeholderNumber(sub)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Text returns a func filling the placeholders of text: {data}, {level} and {chance} are the numbers of the power,
+// {spell} the spell name of data, {skill} the hero skill name of data and {type} the power type.
+// Numbers take a + prefix to be signed, *scale to be multiplied and :decimals to be rounded, in that order,
+// so "{+data}% Fire Resistance" renders "+5% Fire Resistance" and "{data*0.1:1} seconds" renders "1.5 seconds" for 15.
+// Check text with CheckText first, bad number formats are left as written
+func Text(text string) PowerFunc {
+	return func(r *Renderer, power RecordPower) (string, error) {
+		var err error
+		out := placeholderRe.ReplaceAllStringFunc(text, func(m string) string {
+			sub := placeholderRe.FindStringSubmatch(m)
+			number := func(value float64) string {
+				n, numErr := placeholderNumber(sub)
+				if numErr != nil {
+					return m
+				}
+				return n.Format(value)
+			}
+			switch sub[2] {
+			case "data":
+				return number(power.Value)
+			case "level":
+				return number(float64(power.Level))
+			case "chance":
+				return number(float64(power.Chance))
+			case "spell":
+				name, ok := r.SpellName(power.Data)
+				if !ok {
+					err = fmt.Errorf("%s %d: %w", strings.ToLower(string(power.Type)), power.Data, ErrUnknownSpell)
+				}
+				return name
+			case "skill":
+				name, ok := r.HeroSkillName(power.Data)
+				if !ok {
+					return fmt.Sprintf("Hero Skill %d", power.Data)
+				}
+				return name
+			case "type":
+				return string(power.Type)
+			}
+			return m
+		})
+		if err != nil {
+			return "", err
+		}
+		return out, nil
+	}
+}
+
+// LoadPowerTexts reads a Text per power type from a .csv file with a type,text header or a .json object of type to text
+func LoadPowerTexts(path string) (map[PowerType]string, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParsePowerTextsCSV(r)
+	case ".json":
+		texts := map[PowerType]string{}
+		err = json.NewDecoder(r).Decode(&texts)
+		if err != nil {
+			return nil, fmt.Errorf("decode json: %w", err)
+		}
+		return texts, nil
+	}
+	return nil, fmt.Errorf("%s: unsupported power format file, use .csv or .json", path)
+}
+
+// ParsePowerTextsCSV reads a Text per power type with a type,text header
+func ParsePowerTextsCSV(r io.Reader) (map[PowerType]string, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 2
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	if len(records) == 0 || !strings.EqualFold(records[0][0], "type") || !strings.EqualFold(records[0][1], "text") {
+		return nil, fmt.Errorf("read csv: missing type,text header")
+	}
+
+	texts := map[PowerType]string{}
+	seen := make(map[string]bool)
+	for i, record := range records[1:] {
+		powerType := PowerType(strings.TrimSpace(record[0]))
+		if seen[powerType.Key()] {
+			return nil, fmt.Errorf("row %d: duplicate type %s", i+2, powerType)
+		}
+		seen[powerType.Key()] = true
+		texts[powerType] = record[1]
+	}
+	return texts, nil
+}
+
+// castSpell renders a Cast Spell power with the phrase of its CastMode, such as "Casts Fireball level 2 (10% chance per hit)"
+func castSpell(r *Renderer, power RecordPower) (string, error) {
+	_, ok := r.SpellName(power.Data)
+	if !ok {
+		return "", fmt.Errorf("cast spell %d: %w", power.Data, ErrUnknownSpell)
+	}
+	keys := castPhrases[power.CastMode()]
+	key := keys[0]
+	if power.Level > 0 {
+		key = keys[1]
+	}
+	return Text(r.Phrases.Get(key))(r, power)
+}
+
+// heroSkill renders a Hero Skill power, such as "+2 Mighty Blow"
+func heroSkill(r *Renderer, power RecordPower) (string, error) {
+	skillName, ok := r.HeroSkillName(power.Data)
+	if !ok {
+		return r.unknownHeroSkill(power.Data), nil
+	}
+	return FormatNumber(float64(power.Level), true, -1) + " " + skillName, nil
+}
@@ -0,0 +1,129 @@
+package item
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDefaultPowerFormats(t *testing.T) {
+	tests := []struct {
+		power RecordPower
+		known bool
+		want  string
+	}{
+		{RecordPower{Type: "speed", Value: 3, Data: 3}, true, "+3 Movement Speed"},
+		{RecordPower{Type: "Hero Skill", Value: 34, Data: 34, Level: 2}, true, "+2 Mighty Blow"},
+		{RecordPower{Type: "armor", Value: 2, Data: 2}, false, "+2 armor"},
+		{RecordPower{Type: "Stun", Value: 15, Data: 15, Chance: 10}, false, "+15 Stun"},
+		{RecordPower{Type: "HP Regen", Value: 1.55, Data: 1}, false, "+1.55 HP Regen"},
+	}
+	r := &Renderer{}
+	for _, tt := range tests {
+		if r.KnownPower(tt.power.Type) != tt.known {
+			t.Errorf("KnownPower(%q) = %v, want %v", tt.power.Type, !tt.known, tt.known)
+		}
+		got, err := r.Power(tt.power)
+		if err != nil || got != tt.want {
+			t.Errorf("Power(%q) = %q, %v, want %q", tt.power.Type, got, err, tt.want)
+		}
+	}
+}
+
+func TestParsePowerTextsCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    map[PowerType]string
+		wantErr string
+	}{
+		{"texts", "type,text\n# mod powers\n Resist Fire ,{+data}% Fire Resistance\nStun,Stuns for {data*0.1:1} seconds\n", map[PowerType]string{"Resist Fire": "{+data}% Fire Resistance", "Stun": "Stuns for {data*0.1:1} seconds"}, ""},
+		{"no header", "Stun,Stuns\n", nil, "missing type,text header"},
+		{"missing text", "type,text\nStun\n", nil, "read csv"},
+		{"duplicate type", "type,text\nStun,Stuns\nSTUN,Stuns again\n", nil, "row 3: duplicate type STUN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePowerTextsCSV(strings.NewReader(tt.in))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadPowerTexts(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		file    string
+		content string
+		want    map[PowerType]string
+		wantErr string
+	}{
+		{"powers.csv", "type,text\nStun,Stuns\n", map[PowerType]string{"Stun": "Stuns"}, ""},
+		{"powers.json", `{"Stun": "Stuns", "Armor": "{+data} Armor"}`, map[PowerType]string{"Stun": "Stuns", "Armor": "{+data} Armor"}, ""},
+		{"bad.json", `["Stun"]`, nil, "decode json"},
+		{"powers.yaml", "Stun: Stuns", nil, "unsupported power format file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			err := os.WriteFile(path, []byte(tt.content), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadPowerTexts(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPowerFormatsMerge(t *testing.T) {
+	formats := DefaultPowerFormats()
+	err := formats.Merge(map[PowerType]string{"speed": "{+data} Bewegung", "Resist Fire": "{+data}% Fire Resistance"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &Renderer{Formats: formats}
+	tests := []struct {
+		power RecordPower
+		want  string
+	}{
+		{RecordPower{Type: "Speed", Value: 3}, "+3 Bewegung"},
+		{RecordPower{Type: "resist fire", Value: 5}, "+5% Fire Resistance"},
+		{RecordPower{Type: "Hero Skill", Data: 34, Level: 1}, "+1 Mighty Blow"},
+	}
+	for _, tt := range tests {
+		got, err := r.Power(tt.power)
+		if err != nil || got != tt.want {
+			t.Errorf("Power(%q) = %q, %v, want %q", tt.power.Type, got, err, tt.want)
+		}
+	}
+
+	err = DefaultPowerFormats().Merge(map[PowerType]string{"Stun": "{seconds}"})
+	if err == nil || !strings.Contains(err.Error(), "Stun: unknown placeholder {seconds}") {
+		t.Errorf("Merge with a bad placeholder = %v", err)
+	}
+}
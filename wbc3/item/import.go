@@ -0,0 +1,287 @@
+package item
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Change is a single field of an item changed by an import
+type Change struct {
+	ItemID string
+	Field  string
+	Old    string
+	New    string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("item %s %s: %q -> %q", c.ItemID, c.Field, c.Old, c.New)
+}
+
+// RowError is a row of an import that could not be applied
+type RowError struct {
+	Row int
+	Err string
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+}
+
+// RowErrors is every row of an import that could not be applied
+type RowErrors []*RowError
+
+func (e RowErrors) Error() string {
+	lines := []string{}
+	for _, rowErr := range e {
+		lines = append(lines, rowErr.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// importField maps a csv column to the item.xml field it updates
+type importField struct {
+	column  string
+	field   string
+	numeric bool
+	// fold compares ignoring case, for columns the export normalizes
+	fold bool
+	get  func(item *Item) string
+	set  func(item *Item, value string)
+}
+
+var importFields = []importField{
+	{column: "name", field: "Name", get: func(i *Item) string { return i.Name }, set: func(i *Item, v string) { i.Name = v }},
+	{column: "description", field: "Description", get: func(i *Item) string { return i.Description }, set: func(i *Item, v string) { i.Description = v }},
+	{column: "rarity", field: "Data.rarity", fold: true, get: func(i *Item) string { return i.Data.Rarity }, set: func(i *Item, v string) { i.Data.Rarity = v }},
+	{column: "level", field: "Data.level", fold: true, get: func(i *Item) string { return i.Data.Level }, set: func(i *Item, v string) { i.Data.Level = v }},
+	{column: "value", field: "Data.value", numeric: true, get: func(i *Item) string { return i.Data.Value }, set: func(i *Item, v string) { i.Data.Value = v }},
+	{column: "durability", field: "Durability", numeric: true, get: func(i *Item) string { return i.Durability }, set: func(i *Item, v string) { i.Durability = v }},
+	{column: "iconrow", field: "Image.iconrow", numeric: true, get: func(i *Item) string { return i.Image.Iconrow }, set: func(i *Item, v string) { i.Image.Iconrow = v }},
+	{column: "iconcol", field: "Image.iconcol", numeric: true, get: func(i *Item) string { return i.Image.Iconcol }, set: func(i *Item, v string) { i.Image.Iconcol = v }},
+	{column: "str", field: "Req.str", numeric: true, get: func(i *Item) string { return i.Req.Str }, set: func(i *Item, v string) { i.Req.Str = v }},
+	{column: "int", field: "Req.int", numeric: true, get: func(i *Item) string { return i.Req.Int }, set: func(i *Item, v string) { i.Req.Int = v }},
+	{column: "dex", field: "Req.dex", numeric: true, get: func(i *Item) string { return i.Req.Dex }, set: func(i *Item, v string) { i.Req.Dex = v }},
+	{column: "cha", field: "Req.cha", numeric: true, get: func(i *Item) string { return i.Req.Cha }, set: func(i *Item, v string) { i.Req.Cha = v }},
+	{column: "cursed", field: "Curse[0].data", numeric: true, get: func(i *Item) string { return curseOf(i).Data }, set: func(i *Item, v string) { setCurse(i).Data = v }},
+	{column: "heavilycursed", field: "Curse[0].heavilycursed", numeric: true, get: func(i *Item) string { return curseOf(i).Heavilycursed }, set: func(i *Item, v string) { setCurse(i).Heavilycursed = v }},
+}
+
+// importPowerFields is every power attribute an import updates, read from pN_<column>
+var importPowerFields = []struct {
+	column  string
+	numeric bool
+	fold    bool
+	get     func(p *Power) string
+	set     func(p *Power, value string)
+}{
+	{column: "type", fold: true, get: func(p *Power) string { return p.Type }, set: func(p *Power, v string) { p.Type = v }},
+	{column: "data", numeric: true, get: func(p *Power) string { return p.Data }, set: func(p *Power, v string) { p.Data = v }},
+	{column: "level", numeric: true, get: func(p *Power) string { return p.Level }, set: func(p *Power, v string) { p.Level = v }},
+	{column: "chance", numeric: true, get: func(p *Power) string { return p.Chance }, set: func(p *Power, v string) { p.Chance = v }},
+}
+
+func curseOf(item *Item) Curse {
+	if len(item.Curse) == 0 {
+		return Curse{}
+	}
+	return item.Curse[0]
+}
+
+func setCurse(item *Item) *Curse {
+	if len(item.Curse) == 0 {
+		item.Curse = append(item.Curse, Curse{})
+	}
+	return &item.Curse[0]
+}
+
+// ImportCSV applies a csv or tsv in the WriteCSV layout to items, matching rows by id.
+// Derived columns such as slot and pN_text are ignored, and values equal to the current one
+// (numerically, or ignoring case for normalized columns) are left untouched.
+// Nothing is applied when any row fails, such as a row with an unknown id, and the error is a RowErrors
+func ImportCSV(items *Items, r io.Reader, comma rune) ([]Change, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("read csv: empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	_, ok := columns["id"]
+	if !ok {
+		return nil, fmt.Errorf("read csv: missing id column")
+	}
+	powerCount := 0
+	for {
+		_, ok := columns[fmt.Sprintf("p%d_type", powerCount+1)]
+		if !ok {
+			break
+		}
+		powerCount++
+	}
+
+	byID := make(map[string]*Item)
+	for i := range items.Items {
+		byID[strings.TrimSpace(items.Items[i].ID)] = &items.Items[i]
+	}
+
+	cell := func(row []string, column string) (string, bool) {
+		i, ok := columns[column]
+		if !ok || i >= len(row) {
+			return "", false
+		}
+		return strings.TrimSpace(row[i]), true
+	}
+
+	// work on copies so a failing row leaves items untouched
+	updated := make(map[string]Item)
+	changes := []Change{}
+	errs := RowErrors{}
+	seen := make(map[string]int)
+	for n, row := range rows[1:] {
+		rowNumber := n + 2
+		id, _ := cell(row, "id")
+		if id == "" {
+			errs = append(errs, &RowError{Row: rowNumber, Err: "missing id"})
+			continue
+		}
+		first, ok := seen[id]
+		if ok {
+			errs = append(errs, &RowError{Row: rowNumber, Err: fmt.Sprintf("duplicate id %s, first seen on row %d", id, first)})
+			continue
+		}
+		seen[id] = rowNumber
+
+		original, ok := byID[id]
+		if !ok {
+			errs = append(errs, &RowError{Row: rowNumber, Err: fmt.Sprintf("unknown item id %s", id)})
+			continue
+		}
+		item := *original
+		changed := len(changes)
+		item.Power = append([]Power{}, original.Power...)
+		item.Curse = append([]Curse{}, original.Curse...)
+
+		for _, f := range importFields {
+			value, ok := cell(row, f.column)
+			if !ok {
+				continue
+			}
+			old := f.get(&item)
+			same, err := sameValue(old, value, f.numeric, f.fold)
+			if err != nil {
+				errs = append(errs, &RowError{Row: rowNumber, Err: fmt.Sprintf("%s: %s", f.column, err)})
+				continue
+			}
+			if same {
+				continue
+			}
+			f.set(&item, value)
+			changes = append(changes, Change{ItemID: id, Field: f.field, Old: old, New: value})
+		}
+
+		powers := 0
+		for i := 1; i <= powerCount; i++ {
+			empty := true
+			for _, f := range importPowerFields {
+				value, _ := cell(row, fmt.Sprintf("p%d_%s", i, f.column))
+				if value != "" {
+					empty = false
+				}
+			}
+			if empty {
+				continue
+			}
+			if powers != i-1 {
+				errs = append(errs, &RowError{Row: rowNumber, Err: fmt.Sprintf("p%d is set but p%d is empty", i, powers+1)})
+				break
+			}
+			powers = i
+		}
+
+		for i := 0; i < powers; i++ {
+			if i >= len(item.Power) {
+				item.Power = append(item.Power, Power{})
+				changes = append(changes, Change{ItemID: id, Field: fmt.Sprintf("Power[%d]", i), Old: "", New: "added"})
+			}
+			power := &item.Power[i]
+			for _, f := range importPowerFields {
+				value, _ := cell(row, fmt.Sprintf("p%d_%s", i+1, f.column))
+				old := f.get(power)
+				same, err := sameValue(old, value, f.numeric, f.fold)
+				if err != nil {
+					errs = append(errs, &RowError{Row: rowNumber, Err: fmt.Sprintf("p%d_%s: %s", i+1, f.column, err)})
+					continue
+				}
+				if same {
+					continue
+				}
+				f.set(power, value)
+				changes = append(changes, Change{ItemID: id, Field: fmt.Sprintf("Power[%d].%s", i, f.column), Old: old, New: value})
+			}
+		}
+		// powers past the csv columns are kept, the ones the csv left empty are removed
+		end := len(item.Power)
+		if end > powerCount {
+			end = powerCount
+		}
+		for i := powers; i < end; i++ {
+			changes = append(changes, Change{ItemID: id, Field: fmt.Sprintf("Power[%d]", i), Old: item.Power[i].Type, New: "removed"})
+		}
+		if powers < end {
+			item.Power = append(item.Power[:powers], item.Power[end:]...)
+		}
+
+		if len(changes) > changed {
+			updated[id] = item
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	for id, item := range updated {
+		*byID[id] = item
+	}
+	return changes, nil
+}
+
+// sameValue reports if value would leave old unchanged
+func sameValue(old string, value string, numeric bool, fold bool) (bool, error) {
+	old = strings.TrimSpace(old)
+	if old == value {
+		return true, nil
+	}
+	if fold && strings.EqualFold(old, value) {
+		return true, nil
+	}
+	if !numeric {
+		return false, nil
+	}
+	newNum, err := numberOrZero(value)
+	if err != nil {
+		return false, err
+	}
+	oldNum, err := numberOrZero(old)
+	if err != nil {
+		return false, nil
+	}
+	return oldNum == newNum, nil
+}
+
+func numberOrZero(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
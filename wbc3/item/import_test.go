@@ -0,0 +1,207 @@
+package item
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const importSource = `<Items>
+	<Item id="1">
+		<Name>Sword</Name>
+		<Power type="armor" data="2"/>
+		<Data value="500" level="greater" rarity="Rare"/>
+	</Item>
+	<Item id="2">
+		<Name>Ring</Name>
+		<Data value="50"/>
+	</Item>
+</Items>`
+
+const importHeader = "id,value,rarity,p1_type,p1_data,p1_level,p1_chance,p2_type,p2_data,p2_level,p2_chance\n"
+
+func TestImportCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		changes []string
+		check   func(t *testing.T, items *Items)
+	}{
+		{
+			name:    "unchanged values",
+			csv:     importHeader + "1,500.0,rare,Armor,2,,,,,,\n2,50,,,,,,,,,\n",
+			changes: []string{},
+		},
+		{
+			name:    "change value",
+			csv:     importHeader + "1,600,Rare,armor,2,,,,,,\n",
+			changes: []string{`item 1 Data.value: "500" -> "600"`},
+			check: func(t *testing.T, items *Items) {
+				if items.Items[0].Data.Value != "600" {
+					t.Errorf("Data.Value = %q, want 600", items.Items[0].Data.Value)
+				}
+			},
+		},
+		{
+			name: "add power",
+			csv:  importHeader + "2,50,,speed,3,1,,,,,\n",
+			changes: []string{
+				`item 2 Power[0]: "" -> "added"`,
+				`item 2 Power[0].type: "" -> "speed"`,
+				`item 2 Power[0].data: "" -> "3"`,
+				`item 2 Power[0].level: "" -> "1"`,
+			},
+			check: func(t *testing.T, items *Items) {
+				want := []Power{{Type: "speed", Data: "3", Level: "1"}}
+				if !reflect.DeepEqual(items.Items[1].Power, want) {
+					t.Errorf("Power = %+v, want %+v", items.Items[1].Power, want)
+				}
+			},
+		},
+		{
+			name:    "remove power",
+			csv:     importHeader + "1,500,Rare,,,,,,,,\n",
+			changes: []string{`item 1 Power[0]: "armor" -> "removed"`},
+			check: func(t *testing.T, items *Items) {
+				if len(items.Items[0].Power) != 0 {
+					t.Errorf("Power = %+v, want none", items.Items[0].Power)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := Parse(strings.NewReader(importSource))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			changes, err := ImportCSV(items, strings.NewReader(tt.csv), ',')
+			if err != nil {
+				t.Fatalf("ImportCSV: %v", err)
+			}
+			got := []string{}
+			for _, change := range changes {
+				got = append(got, change.String())
+			}
+			if !reflect.DeepEqual(got, tt.changes) {
+				t.Errorf("changes = %q, want %q", got, tt.changes)
+			}
+			if tt.check != nil {
+				tt.check(t, items)
+			}
+		})
+	}
+}
+
+func TestImportCSVRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		csv  string
+		rows []int
+	}{
+		{"unknown id", importHeader + "1,600,,,,,,,,,\n99,1,,,,,,,,,\n", []int{3}},
+		{"duplicate id", importHeader + "1,600,,,,,,,,,\n1,700,,,,,,,,,\n", []int{3}},
+		{"missing id", importHeader + "1,600,,,,,,,,,\n,700,,,,,,,,,\n", []int{3}},
+		{"not a number", importHeader + "1,abc,,,,,,,,,\n", []int{2}},
+		{"power gap", importHeader + "2,50,,,,,,speed,1,,\n", []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := Parse(strings.NewReader(importSource))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			before, _ := Parse(strings.NewReader(importSource))
+
+			changes, err := ImportCSV(items, strings.NewReader(tt.csv), ',')
+			var rowErrs RowErrors
+			if !errors.As(err, &rowErrs) {
+				t.Fatalf("ImportCSV error = %v, want RowErrors", err)
+			}
+			if changes != nil {
+				t.Errorf("changes = %v, want none", changes)
+			}
+			rows := []int{}
+			for _, rowErr := range rowErrs {
+				rows = append(rows, rowErr.Row)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("failed rows = %v, want %v", rows, tt.rows)
+			}
+			if !reflect.DeepEqual(items, before) {
+				t.Errorf("items were changed by a failed import")
+			}
+		})
+	}
+}
+
+func TestImportCSVKeepsInvalidNumbers(t *testing.T) {
+	source := `<Items><Item id="1"><Name>Sword</Name><Power type="armor" data="x2"/><Data value="abc"/><Req str="ten"/><Curse data="?"/></Item></Items>`
+	items, err := Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	records, err := Decode(items, nil)
+	if err == nil {
+		t.Fatal("Decode: want field errors")
+	}
+	resolved, _ := (&Renderer{}).Resolve(records[0])
+	if resolved.Invalid["Data.value"] != "abc" {
+		t.Errorf("Invalid = %v, want Data.value abc", resolved.Invalid)
+	}
+
+	buf := &strings.Builder{}
+	err = WriteCSV(buf, []Resolved{resolved}, ',')
+	if err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	changes, err := ImportCSV(items, strings.NewReader(buf.String()), ',')
+	if err != nil {
+		t.Fatalf("ImportCSV: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes = %v, want none", changes)
+	}
+}
+
+func TestImportCSVExportUnchanged(t *testing.T) {
+	source := `<Items>
+	<Item id="1"><Name>Sword</Name><Data value="5"/><Curse data="0"/><Curse data="1" heavilycursed="1"/></Item>
+	<Item id="2"><Name>Ring</Name><Curse data="1"/></Item>
+	<Item id="3"><Name>Axe</Name></Item>
+</Items>`
+	items, err := Parse(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	records, err := Decode(items, nil)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !records[0].Cursed || !records[0].HeavyCursed {
+		t.Errorf("item 1 Cursed, HeavyCursed = %v, %v, want both from its second Curse", records[0].Cursed, records[0].HeavyCursed)
+	}
+	resolved := []Resolved{}
+	for _, record := range records {
+		entry, _ := (&Renderer{}).Resolve(record)
+		resolved = append(resolved, entry)
+	}
+
+	buf := &strings.Builder{}
+	err = WriteCSV(buf, resolved, ',')
+	if err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	before, _ := Parse(strings.NewReader(source))
+	changes, err := ImportCSV(items, strings.NewReader(buf.String()), ',')
+	if err != nil {
+		t.Fatalf("ImportCSV: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes = %v, want none", changes)
+	}
+	if !reflect.DeepEqual(items, before) {
+		t.Errorf("items were changed by importing their own export")
+	}
+}
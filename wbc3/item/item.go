@@ -0,0 +1,106 @@
+// Package item reads and formats the Warlords Battlecry 3 item.xml database
+package item
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Items is the root element of item.xml
+type Items struct {
+	XMLName xml.Name   `xml:"Items"`
+	Text    string     `xml:",chardata"`
+	Items   []Item     `xml:"Item"`
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Extra   []Element  `xml:",any"`
+}
+
+// Item is a single <Item> entry
+type Item struct {
+	Text        string  `xml:",chardata"`
+	ID          string  `xml:"id,attr,omitempty"`
+	Name        string  `xml:"Name"`
+	Power       []Power `xml:"Power"`
+	Description string  `xml:"Description"`
+	Image       Image   `xml:"Image"`
+	Data        Data    `xml:"Data"`
+	Sound       []Sound `xml:"Sound"`
+	Curse       []Curse `xml:"Curse"`
+	Durability  string  `xml:"Durability"`
+	Req         Req     `xml:"Req"`
+	// Attrs and Extra hold what the fields above do not model, see FindUnmodeled
+	Attrs []xml.Attr `xml:",any,attr"`
+	Extra []Element  `xml:",any"`
+}
+
+// Power is an effect granted by an item
+type Power struct {
+	Text   string     `xml:",chardata" json:"-"`
+	ID     string     `xml:"id,attr,omitempty" json:"id,omitempty"`
+	Type   string     `xml:"type,attr,omitempty" json:"type,omitempty"`
+	Data   string     `xml:"data,attr,omitempty" json:"data,omitempty"`
+	Level  string     `xml:"level,attr,omitempty" json:"level,omitempty"`
+	Chance string     `xml:"chance,attr,omitempty" json:"chance,omitempty"`
+	Attrs  []xml.Attr `xml:",any,attr" json:"-"`
+	Extra  []Element  `xml:",any" json:"-"`
+}
+
+// Image is the icon position of an item
+type Image struct {
+	Text    string     `xml:",chardata"`
+	Iconrow string     `xml:"iconrow,attr,omitempty"`
+	Iconcol string     `xml:"iconcol,attr,omitempty"`
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Extra   []Element  `xml:",any"`
+}
+
+// Data holds the value, level and rarity of an item
+type Data struct {
+	Text   string     `xml:",chardata"`
+	Value  string     `xml:"value,attr,omitempty"`
+	Level  string     `xml:"level,attr,omitempty"`
+	Rarity string     `xml:"rarity,attr,omitempty"`
+	Attrs  []xml.Attr `xml:",any,attr"`
+	Extra  []Element  `xml:",any"`
+}
+
+// Sound is the sound set of an item, pickup is also used to tell the slot
+type Sound struct {
+	Text   string     `xml:",chardata"`
+	Damage string     `xml:"damage,attr,omitempty"`
+	Pickup string     `xml:"pickup,attr,omitempty"`
+	Skin   string     `xml:"skin,attr,omitempty"`
+	Attrs  []xml.Attr `xml:",any,attr"`
+	Extra  []Element  `xml:",any"`
+}
+
+// Curse flags an item as cursed
+type Curse struct {
+	Text          string     `xml:",chardata"`
+	Data          string     `xml:"data,attr,omitempty"`
+	Heavilycursed string     `xml:"heavilycursed,attr,omitempty"`
+	Attrs         []xml.Attr `xml:",any,attr"`
+	Extra         []Element  `xml:",any"`
+}
+
+// Req is the stats required to equip an item
+type Req struct {
+	Text  string     `xml:",chardata"`
+	Str   string     `xml:"str,attr,omitempty"`
+	Int   string     `xml:"int,attr,omitempty"`
+	Dex   string     `xml:"dex,attr,omitempty"`
+	Cha   string     `xml:"cha,attr,omitempty"`
+	Attrs []xml.Attr `xml:",any,attr"`
+	Extra []Element  `xml:",any"`
+}
+
+// Parse decodes an item.xml document
+func Parse(r io.Reader) (*Items, error) {
+	items := &Items{}
+	err := xml.NewDecoder(r).Decode(items)
+	if err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,156 @@
+package item
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xackery/wbc3-cli/wbc3/skill"
+	"github.com/xackery/wbc3-cli/wbc3/strtab"
+)
+
+// checks run by Lint, each Problem carries one of them
+const (
+	CheckMissingID     = "missing-id"
+	CheckDuplicateID   = "duplicate-id"
+	CheckMissingName   = "missing-name"
+	CheckNotNumeric    = "not-numeric"
+	CheckHeroSkill     = "unknown-hero-skill"
+	CheckSpell         = "unknown-spell"
+	CheckPickup        = "unknown-pickup"
+	CheckTooManyPowers = "too-many-powers"
+	CheckPowerType     = "unknown-power-type"
+	CheckRarity        = "unknown-rarity"
+	CheckLevel         = "unknown-level"
+)
+
+// Problem is a mistake in item.xml found by Lint
+type Problem struct {
+	Check string
+	// Item is the index of the item in Items.Items
+	Item   int
+	ItemID string
+	// Field is the offending field, such as Power[1].data, empty for the item itself
+	Field   string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s [%s]", p.Location(), p.Message, p.Check)
+}
+
+// Location names the item and field of the problem, such as "item 12 Power[1].data"
+func (p Problem) Location() string {
+	where := "item " + p.ItemID
+	if p.ItemID == "" {
+		where = fmt.Sprintf("item #%d", p.Item+1)
+	}
+	if p.Field != "" {
+		where += " " + p.Field
+	}
+	return where
+}
+
+// Linter checks item.xml for mistakes the game or the exporters would trip over
+type Linter struct {
+	// Text resolves spell names, spells are not checked when nil
+	Text strtab.Lookup
+	// Skills is the hero skill table, skill.Default() when nil
+	Skills *skill.Table
+	// Slots tells the slot of a pickup sound, DefaultSlotMap() when nil
+	Slots SlotMap
+	// Formats is the power types that have a format, DefaultPowerFormats() when nil
+	Formats *PowerFormats
+}
+
+// Lint returns every problem of items, in item order
+func (l *Linter) Lint(items *Items) []Problem {
+	skills := l.Skills
+	if skills == nil {
+		skills = skill.Default()
+	}
+	slots := l.Slots
+	if slots == nil {
+		slots = DefaultSlotMap()
+	}
+	renderer := &Renderer{Text: l.Text, Skills: skills, Formats: l.Formats}
+
+	problems := []Problem{}
+	seen := make(map[string]int)
+	for i := range items.Items {
+		it := &items.Items[i]
+		id := strings.TrimSpace(it.ID)
+		add := func(check string, field string, format string, a ...interface{}) {
+			problems = append(problems, Problem{Check: check, Item: i, ItemID: id, Field: field, Message: fmt.Sprintf(format, a...)})
+		}
+
+		if id == "" {
+			add(CheckMissingID, "id", "no id")
+		} else if first, ok := seen[id]; ok {
+			add(CheckDuplicateID, "id", "duplicate id, first used by item #%d", first+1)
+		} else {
+			seen[id] = i
+		}
+		if strings.TrimSpace(it.Name) == "" {
+			add(CheckMissingName, "Name", "no name")
+		}
+
+		record, err := it.Record(slots)
+		bad := make(map[string]bool)
+		fieldErrs, _ := err.(FieldErrors)
+		for _, fieldErr := range fieldErrs {
+			bad[fieldErr.Field] = true
+			add(CheckNotNumeric, fieldErr.Field, "%q is %s", fieldErr.Value, fieldErr.Err)
+		}
+
+		if len(record.Powers) > MaxPowers {
+			add(CheckTooManyPowers, fmt.Sprintf("Power[%d]", MaxPowers), "%d powers, only %d are supported", len(record.Powers), MaxPowers)
+		}
+		for n, power := range record.Powers {
+			if !renderer.KnownPower(power.Type) {
+				add(CheckPowerType, fmt.Sprintf("Power[%d].type", n), "power type %q has no format, rendered as \"+data %s\"", power.Raw.Type, power.Type)
+			}
+			field := fmt.Sprintf("Power[%d].data", n)
+			if bad[field] {
+				continue
+			}
+			switch {
+			case power.Type.Is(PowerCastSpell):
+				if l.Text == nil {
+					continue
+				}
+				_, ok := renderer.SpellName(power.Data)
+				if !ok {
+					add(CheckSpell, field, "spell %d has no %s%d in the string tables", power.Data, SpellNameKey, power.Data)
+				}
+			case power.Type.Is(PowerHeroSkill):
+				_, ok := skills.Get(power.Data)
+				if !ok {
+					add(CheckHeroSkill, field, "hero skill %d is not in the hero skill table", power.Data)
+				}
+			}
+		}
+
+		if !record.KnownRarity {
+			add(CheckRarity, "Data.rarity", "rarity %q is not a known rarity", record.Rarity)
+		}
+		if !record.KnownLevel {
+			add(CheckLevel, "Data.level", "level %q is not a known level", record.Level)
+		}
+
+		if !record.KnownSlot {
+			if record.Pickup == "" {
+				add(CheckPickup, "Sound", "no pickup sound, slot unknown")
+				continue
+			}
+			field := "Sound"
+			for n, sound := range it.Sound {
+				if sound.Pickup != "" {
+					field = fmt.Sprintf("Sound[%d].pickup", n)
+					break
+				}
+			}
+			add(CheckPickup, field, "pickup sound %s has no slot", record.Pickup)
+		}
+	}
+	return problems
+}
@@ -0,0 +1,48 @@
+package item
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xackery/wbc3-cli/wbc3/strtab"
+)
+
+func TestLint(t *testing.T) {
+	text := strtab.Table{"SPELL_NAME_12": "Fireball"}
+	tests := []struct {
+		name   string
+		linter *Linter
+		items  string
+		want   []string
+	}{
+		{"clean", &Linter{Text: text}, `<Item id="1"><Name>Rod</Name><Power type="Cast Spell" data="12"/><Power type="Hero Skill" data="34"/><Sound pickup="Rod"/></Item>`, []string{}},
+		{"missing id and name", &Linter{}, `<Item><Sound pickup="Rod"/></Item>`, []string{"missing-id item #1 id", "missing-name item #1 Name"}},
+		{"duplicate id", &Linter{}, `<Item id="1"><Name>A</Name><Sound pickup="Rod"/></Item><Item id="1"><Name>B</Name><Sound pickup="Rod"/></Item>`, []string{"duplicate-id item 1 id"}},
+		{"not numeric", &Linter{}, `<Item id="1"><Name>A</Name><Data value="abc"/><Power type="Hero Skill" data="1.5"/><Sound pickup="Rod"/></Item>`, []string{"not-numeric item 1 Data.value", "not-numeric item 1 Power[0].data"}},
+		{"unknown spell", &Linter{Text: text}, `<Item id="1"><Name>A</Name><Power type="Cast Spell" data="13"/><Sound pickup="Rod"/></Item>`, []string{"unknown-spell item 1 Power[0].data"}},
+		{"spells unchecked without text", &Linter{}, `<Item id="1"><Name>A</Name><Power type="Cast Spell" data="13"/><Sound pickup="Rod"/></Item>`, []string{}},
+		{"unknown hero skill", &Linter{}, `<Item id="1"><Name>A</Name><Power type="hero skill" data="999"/><Sound pickup="Rod"/></Item>`, []string{"unknown-hero-skill item 1 Power[0].data"}},
+		{"unknown power type", &Linter{}, `<Item id="1"><Name>A</Name><Power type="Armor" data="2"/><Sound pickup="Rod"/></Item>`, []string{"unknown-power-type item 1 Power[0].type"}},
+		{"too many powers", &Linter{}, `<Item id="1"><Name>A</Name>` + strings.Repeat(`<Power type="Speed" data="1"/>`, 5) + `<Sound pickup="Rod"/></Item>`, []string{"too-many-powers item 1 Power[4]"}},
+		{"unknown rarity and level", &Linter{}, `<Item id="1"><Name>A</Name><Data rarity="Epic" level="Huge"/><Sound pickup="Rod"/></Item>`, []string{"unknown-rarity item 1 Data.rarity", "unknown-level item 1 Data.level"}},
+		{"no pickup", &Linter{}, `<Item id="1"><Name>A</Name></Item>`, []string{"unknown-pickup item 1 Sound"}},
+		{"unknown pickup", &Linter{}, `<Item id="1"><Name>A</Name><Sound damage="Hit"/><Sound pickup="Lute"/></Item>`, []string{"unknown-pickup item 1 Sound[1].pickup"}},
+		{"pickup from slots", &Linter{Slots: SlotMap{"Lute": "Hand"}}, `<Item id="1"><Name>A</Name><Sound pickup="Lute"/></Item>`, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseDocument(strings.NewReader("<Items>" + tt.items + "</Items>"))
+			if err != nil {
+				t.Fatalf("ParseDocument: %v", err)
+			}
+			got := []string{}
+			for _, problem := range tt.linter.Lint(doc.Items) {
+				got = append(got, problem.Check+" "+problem.Location())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package item
+
+import (
+	"strings"
+)
+
+// MarkdownTable writes items as markdown tables, one per slot
+type MarkdownTable struct {
+	// Columns is the column set, DefaultColumns when empty
+	Columns []Column
+	// CombinePowers writes every power of an item into a single Powers cell instead of a column each
+	CombinePowers bool
+}
+
+// Write renders items grouped by slot, in the order of slots. Items of a slot missing from slots, such as one of
+// an unknown pickup sound, follow in a table per slot. Each table has as many power columns as
+// the item of its slot with the most powers
+func (t MarkdownTable) Write(items []Resolved, slots []Slot) string {
+	bySlot := make(map[Slot][]Resolved)
+	for _, item := range items {
+		bySlot[item.Slot] = append(bySlot[item.Slot], item)
+	}
+
+	out := ""
+	for _, slot := range tableSlots(items, slots) {
+		entries := bySlot[slot]
+		powers := MostPowers(entries)
+		out += "\n\n## " + string(slot) + "\n\n"
+		out += t.Header(powers)
+		for _, entry := range entries {
+			out += t.Row(entry, powers) + "\n"
+		}
+	}
+	return out
+}
+
+// Header returns the header and delimiter row of a table with powers power columns
+func (t MarkdownTable) Header(powers int) string {
+	headers := []string{}
+	for _, header := range columnHeaders(t.columns(), t.CombinePowers, powers) {
+		headers = append(headers, markdownCell(header))
+	}
+
+	delimiter := strings.TrimSuffix(strings.Repeat("-|", len(headers)), "|")
+	return strings.Join(headers, "|") + "\n" + delimiter + "\n"
+}
+
+// Row renders an item as a pipe delimited markdown row with powers power columns
+func (t MarkdownTable) Row(item Resolved, powers int) string {
+	cells := columnCells(t.columns(), t.CombinePowers, item, powers, func(field string, value string) string {
+		return markdownCell(value)
+	})
+	return strings.Join(cells, "|")
+}
+
+func (t MarkdownTable) columns() []Column {
+	if len(t.Columns) == 0 {
+		return DefaultColumns
+	}
+	return t.Columns
+}
+
+// markdownCell escapes pipes and drops line breaks so value stays in its cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	value = strings.ReplaceAll(value, "\r\n", " ")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// MostPowers returns the most powers any of items has
+func MostPowers(items []Resolved) int {
+	most := 0
+	for _, item := range items {
+		if len(item.Powers) > most {
+			most = len(item.Powers)
+		}
+	}
+	return most
+}
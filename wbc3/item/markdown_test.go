@@ -0,0 +1,35 @@
+package item
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownTableListsUnknownSlots(t *testing.T) {
+	items := []Resolved{
+		{ID: 1, Name: "Odd Thing", Slot: "FeatherUNK"},
+		{ID: 2, Name: "Sword", Slot: SlotHand},
+		{ID: 3, Name: "Scroll", Slot: "ScrollUNK"},
+		{ID: 4, Name: "Quill", Slot: "FeatherUNK"},
+	}
+	out := MarkdownTable{Columns: []Column{{Field: "name"}}}.Write(items, []Slot{SlotHand, SlotFinger})
+
+	headings := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			headings = append(headings, strings.TrimPrefix(line, "## "))
+		}
+	}
+	want := []string{"Hand", "Finger", "FeatherUNK", "ScrollUNK"}
+	if strings.Join(headings, ",") != strings.Join(want, ",") {
+		t.Errorf("tables = %v, want %v", headings, want)
+	}
+	for _, item := range items {
+		if !strings.Contains(out, "\n"+item.Name+"\n") {
+			t.Errorf("item %s is missing:\n%s", item.Name, out)
+		}
+	}
+	if !strings.Contains(out, "## FeatherUNK\n\nName\n-\nOdd Thing\nQuill\n") {
+		t.Errorf("FeatherUNK table does not hold both its items in order:\n%s", out)
+	}
+}
@@ -0,0 +1,46 @@
+package item
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FormatNumber formats value rounded half away from zero to decimals digits after the point, with trailing zeros dropped.
+// decimals -1 keeps every digit needed. When signed, values above zero get a + prefix and zero is "+0".
+// Negative values keep a single - and a value rounding to zero never prints as "-0"
+func FormatNumber(value float64, signed bool, decimals int) string {
+	if decimals >= 0 {
+		pow := math.Pow(10, float64(decimals))
+		value = math.Round(value*pow) / pow
+	}
+	out := strconv.FormatFloat(value, 'f', decimals, 64)
+	if strings.Contains(out, ".") {
+		out = strings.TrimRight(out, "0")
+		out = strings.TrimSuffix(out, ".")
+	}
+	if out == "-0" {
+		out = "0"
+	}
+	if signed && !strings.HasPrefix(out, "-") {
+		out = "+" + out
+	}
+	return out
+}
+
+// Number is how a placeholder of a Text formats its value: multiplied by Scale, then rounded to Decimals
+type Number struct {
+	Signed bool
+	// Scale multiplies the value, such as 0.1 for data in tenths of a second, 1 when 0
+	Scale float64
+	// Decimals is the digits kept after the point, -1 for every digit needed
+	Decimals int
+}
+
+// Format formats value as described by n
+func (n Number) Format(value float64) string {
+	if n.Scale != 0 {
+		value *= n.Scale
+	}
+	return FormatNumber(value, n.Signed, n.Decimals)
+}
@@ -0,0 +1,84 @@
+package item
+
+import "testing"
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value    float64
+		signed   bool
+		decimals int
+		want     string
+	}{
+		{0, true, -1, "+0"},
+		{0, false, -1, "0"},
+		{5, true, -1, "+5"},
+		{-5, true, -1, "-5"},
+		{-5, false, -1, "-5"},
+		{1.5, true, -1, "+1.5"},
+		{2.25, false, 1, "2.3"},
+		{-2.25, false, 1, "-2.3"},
+		{2.5, false, 0, "3"},
+		{-2.5, true, 0, "-3"},
+		{-0.04, false, 1, "0"},
+		{-0.04, true, 1, "+0"},
+		{1.50, false, 2, "1.5"},
+		{2.004, false, 2, "2"},
+		{100, false, 2, "100"},
+	}
+	for _, tt := range tests {
+		got := FormatNumber(tt.value, tt.signed, tt.decimals)
+		if got != tt.want {
+			t.Errorf("FormatNumber(%v, %v, %d) = %q, want %q", tt.value, tt.signed, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		text  string
+		power RecordPower
+		want  string
+	}{
+		{"{+data} Armor", RecordPower{Type: "Armor", Value: 2, Data: 2}, "+2 Armor"},
+		{"{+data} Armor", RecordPower{Type: "Armor", Value: -5, Data: -5}, "-5 Armor"},
+		{"{+data} Armor", RecordPower{Type: "Armor"}, "+0 Armor"},
+		{"{+data}% Fire Resistance", RecordPower{Type: "Fire Resistance", Value: 5, Data: 5}, "+5% Fire Resistance"},
+		{"{data*0.1:1} seconds", RecordPower{Type: "Stun", Value: 15, Data: 15}, "1.5 seconds"},
+		{"{data*0.1:1} seconds", RecordPower{Type: "Stun", Value: 20, Data: 20}, "2 seconds"},
+		{"{+data*0.1:1} seconds", RecordPower{Type: "Stun", Value: -15, Data: -15}, "-1.5 seconds"},
+		{"{+data:0} {type}", RecordPower{Type: "Regen", Value: 1.5, Data: 1}, "+2 Regen"},
+		{"{chance}% at level {level}", RecordPower{Type: "Cast Spell", Chance: 10, Level: 3}, "10% at level 3"},
+	}
+	r := &Renderer{}
+	for _, tt := range tests {
+		got, err := Text(tt.text)(r, tt.power)
+		if err != nil {
+			t.Errorf("Text(%q) with %+v: %v", tt.text, tt.power, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Text(%q) with %+v = %q, want %q", tt.text, tt.power, got, tt.want)
+		}
+	}
+}
+
+func TestCheckText(t *testing.T) {
+	tests := []struct {
+		text    string
+		wantErr bool
+	}{
+		{"{+data} Armor", false},
+		{"{data*0.1:1} seconds", false},
+		{"{spell} {skill} {type}", false},
+		{"{nope} Armor", true},
+		{"{data*x} Armor", true},
+		{"{data:x} Armor", true},
+		{"{+spell}", true},
+	}
+	for _, tt := range tests {
+		err := CheckText(tt.text)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckText(%q) = %v, want error %v", tt.text, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package item
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// element is a direct child of an item in its raw bytes
+type element struct {
+	name string
+	// tag is the start tag, content is between the start and end tag, end is the end tag (empty when self closed)
+	tag     span
+	content span
+	end     span
+}
+
+// edit replaces src[start:end] with text
+type edit struct {
+	span
+	text string
+}
+
+// scanItem finds the start tag of an item and every direct child element
+func scanItem(raw []byte) (span, []element, error) {
+	dec := xml.NewDecoder(bytes.NewReader(raw))
+	root := span{}
+	children := []element{}
+	depth := 0
+	for {
+		start := int(dec.InputOffset())
+		token, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return root, nil, err
+		}
+		offset := int(dec.InputOffset())
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 1 {
+				root = span{start: start, end: offset}
+			}
+			if depth == 2 {
+				children = append(children, element{name: t.Name.Local, tag: span{start: start, end: offset}})
+			}
+		case xml.EndElement:
+			if depth == 2 {
+				c := &children[len(children)-1]
+				c.content = span{start: c.tag.end, end: start}
+				c.end = span{start: start, end: offset}
+			}
+			depth--
+		}
+	}
+	return root, children, nil
+}
+
+// patchItem rewrites only the fields of raw that differ between orig and cur, keeping everything else as is
+func patchItem(raw []byte, orig Item, cur Item) ([]byte, error) {
+	root, children, err := scanItem(raw)
+	if err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+
+	edits := []edit{}
+	rootTag := string(raw[root.start:root.end])
+	patchedRoot := patchAttrs(rootTag, reflect.ValueOf(orig), reflect.ValueOf(cur))
+	if patchedRoot != rootTag {
+		edits = append(edits, edit{span: root, text: patchedRoot})
+	}
+
+	childIndent := leadingIndent(raw, root.start, "") + "\t"
+	if len(children) > 0 {
+		childIndent = leadingIndent(raw, children[0].tag.start, childIndent)
+	}
+	// itemEnd is where children missing from the source are appended: after the last child, or after the start tag
+	itemEnd := root.end
+	if len(children) > 0 {
+		itemEnd = children[len(children)-1].end.end
+	}
+
+	typ := reflect.TypeOf(cur)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, opts := xmlTag(field)
+		if name == "" || strings.Contains(opts, "attr") {
+			continue
+		}
+		origValue := reflect.ValueOf(orig).Field(i)
+		curValue := reflect.ValueOf(cur).Field(i)
+		if reflect.DeepEqual(origValue.Interface(), curValue.Interface()) {
+			continue
+		}
+
+		matches := []element{}
+		for _, c := range children {
+			if c.name == name {
+				matches = append(matches, c)
+			}
+		}
+		insertAt := itemEnd
+		if len(matches) > 0 {
+			insertAt = matches[len(matches)-1].end.end
+		}
+
+		values := []reflect.Value{curValue}
+		origValues := []reflect.Value{origValue}
+		if curValue.Kind() == reflect.Slice {
+			values = sliceValues(curValue)
+			origValues = sliceValues(origValue)
+		}
+
+		for n, value := range values {
+			if n >= len(matches) {
+				if value.Kind() != reflect.Slice && value.IsZero() {
+					continue
+				}
+				data, err := marshalElement(value.Interface(), name, childIndent)
+				if err != nil {
+					return nil, fmt.Errorf("encode %s: %w", name, err)
+				}
+				edits = append(edits, edit{span: span{start: insertAt, end: insertAt}, text: "\n" + childIndent + string(data)})
+				continue
+			}
+
+			c := matches[n]
+			if value.Kind() == reflect.String {
+				if n < len(origValues) && origValues[n].String() == value.String() {
+					continue
+				}
+				text := escapeText(value.String())
+				if c.end.start == c.end.end {
+					// self closed, such as <Name/>
+					edits = append(edits, edit{span: c.tag, text: fmt.Sprintf("<%s>%s</%s>", name, text, name)})
+					continue
+				}
+				edits = append(edits, edit{span: c.content, text: text})
+				continue
+			}
+
+			origChild := reflect.Zero(value.Type())
+			if n < len(origValues) {
+				origChild = origValues[n]
+			}
+			tag := string(raw[c.tag.start:c.tag.end])
+			patched := patchAttrs(tag, origChild, value)
+			if patched != tag {
+				edits = append(edits, edit{span: c.tag, text: patched})
+			}
+		}
+
+		// children of a slice that were removed
+		for n := len(values); n < len(matches) && curValue.Kind() == reflect.Slice; n++ {
+			c := matches[n]
+			start := c.tag.start
+			trimmed := trimLine(raw[:start])
+			edits = append(edits, edit{span: span{start: len(trimmed), end: c.end.end}})
+		}
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	out := &bytes.Buffer{}
+	cursor := 0
+	for _, e := range edits {
+		if e.start < cursor {
+			return nil, fmt.Errorf("overlapping edits at offset %d", e.start)
+		}
+		out.Write(raw[cursor:e.start])
+		out.WriteString(e.text)
+		cursor = e.end
+	}
+	out.Write(raw[cursor:])
+	return out.Bytes(), nil
+}
+
+// patchAttrs rewrites the attributes of a start tag that differ between the orig and cur structs
+func patchAttrs(tag string, orig reflect.Value, cur reflect.Value) string {
+	typ := cur.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		name, opts := xmlTag(typ.Field(i))
+		if !strings.Contains(opts, "attr") || name == "" || typ.Field(i).Type.Kind() != reflect.String {
+			continue
+		}
+		value := cur.Field(i).String()
+		if orig.Field(i).String() == value {
+			continue
+		}
+		tag = setAttr(tag, name, value)
+	}
+	return tag
+}
+
+// setAttr sets, adds or (when value is empty) removes an attribute of a start tag
+func setAttr(tag string, name string, value string) string {
+	re := regexp.MustCompile(`(\s+)` + regexp.QuoteMeta(name) + `\s*=\s*("[^"]*"|'[^']*')`)
+	loc := re.FindStringSubmatchIndex(tag)
+	if loc != nil {
+		if value == "" {
+			return tag[:loc[0]] + tag[loc[1]:]
+		}
+		quote := tag[loc[4]]
+		return tag[:loc[4]] + string(quote) + escapeAttr(value, quote) + string(quote) + tag[loc[5]:]
+	}
+	if value == "" {
+		return tag
+	}
+
+	end := len(tag) - 1
+	if strings.HasSuffix(tag, "/>") {
+		end = len(tag) - 2
+	}
+	body := strings.TrimRight(tag[:end], " \t\r\n")
+	return body + fmt.Sprintf(` %s="%s"`, name, escapeAttr(value, '"')) + tag[len(body):]
+}
+
+// xmlTag splits the xml struct tag of a field into its name and options
+func xmlTag(field reflect.StructField) (string, string) {
+	tag := field.Tag.Get("xml")
+	if tag == "" || tag == "-" {
+		return "", ""
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, opts
+}
+
+func sliceValues(v reflect.Value) []reflect.Value {
+	values := []reflect.Value{}
+	for i := 0; i < v.Len(); i++ {
+		values = append(values, v.Index(i))
+	}
+	return values
+}
+
+func escapeText(value string) string {
+	buf := &bytes.Buffer{}
+	xml.EscapeText(buf, []byte(value))
+	return buf.String()
+}
+
+func escapeAttr(value string, quote byte) string {
+	value = strings.ReplaceAll(value, "&", "&amp;")
+	value = strings.ReplaceAll(value, "<", "&lt;")
+	if quote == '\'' {
+		return strings.ReplaceAll(value, "'", "&apos;")
+	}
+	return strings.ReplaceAll(value, `"`, "&quot;")
+}
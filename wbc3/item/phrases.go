@@ -0,0 +1,169 @@
+package item
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// keys of the Phrases a Renderer writes around names and numbers
+const (
+	PhraseCastOnHit        = "cast on hit"
+	PhraseCastOnHitLevel   = "cast on hit with level"
+	PhraseCastOnUse        = "cast on use"
+	PhraseCastOnUseLevel   = "cast on use with level"
+	PhraseCastAura         = "cast aura"
+	PhraseCastAuraLevel    = "cast aura with level"
+	PhraseUnknownSpell     = "unknown spell"
+	PhraseUnknownHeroSkill = "unknown hero skill"
+	PhraseRequirement      = "requirement"
+	PhraseRequirementJoin  = "requirement separator"
+	PhraseStr              = "str"
+	PhraseInt              = "int"
+	PhraseDex              = "dex"
+	PhraseCha              = "cha"
+	PhraseNotCursed        = "not cursed"
+	PhraseCursed           = "cursed"
+	PhraseHeavilyCursed    = "heavily cursed"
+)
+
+// castPhrases is every phrase rendered with Text, by the CastMode it is for and if the power has a level
+var castPhrases = map[CastMode][2]string{
+	CastOnHit: {PhraseCastOnHit, PhraseCastOnHitLevel},
+	CastOnUse: {PhraseCastOnUse, PhraseCastOnUseLevel},
+	CastAura:  {PhraseCastAura, PhraseCastAuraLevel},
+}
+
+// Phrases are the fixed texts of rendered items by key, so a translation can replace them.
+// The cast phrases take the placeholders of Text, such as {spell}, {level} and {chance},
+// the unknown spell and hero skill phrases {data} for the id and the requirement phrase {value} and {stat}
+type Phrases map[string]string
+
+// DefaultPhrases is the English text of every phrase
+var DefaultPhrases = Phrases{
+	PhraseCastOnHit:        "Casts {spell} ({chance}% chance per hit)",
+	PhraseCastOnHitLevel:   "Casts {spell} level {level} ({chance}% chance per hit)",
+	PhraseCastOnUse:        "Casts {spell} when used",
+	PhraseCastOnUseLevel:   "Casts {spell} level {level} when used",
+	PhraseCastAura:         "Casts {spell} as a passive aura",
+	PhraseCastAuraLevel:    "Casts {spell} level {level} as a passive aura",
+	PhraseUnknownSpell:     "Spell {data}",
+	PhraseUnknownHeroSkill: "Hero Skill {data}",
+	PhraseRequirement:      "{value} {stat}",
+	PhraseRequirementJoin:  ", ",
+	PhraseStr:              "STR",
+	PhraseInt:              "INT",
+	PhraseDex:              "DEX",
+	PhraseCha:              "CHA",
+	PhraseNotCursed:        "No",
+	PhraseCursed:           "Yes",
+	PhraseHeavilyCursed:    "Yes (Heavy)",
+}
+
+// Get returns the text of key, or its DefaultPhrases text when p does not have it
+func (p Phrases) Get(key string) string {
+	text, ok := p[key]
+	if ok {
+		return text
+	}
+	return DefaultPhrases[key]
+}
+
+// PhraseKeys returns the key of every phrase, sorted
+func PhraseKeys() []string {
+	keys := []string{}
+	for key := range DefaultPhrases {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LoadPhrases reads phrases from a .csv file with a key,text header or a .json object of key to text
+func LoadPhrases(path string) (Phrases, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParsePhrasesCSV(r)
+	case ".json":
+		phrases := Phrases{}
+		err = json.NewDecoder(r).Decode(&phrases)
+		if err != nil {
+			return nil, fmt.Errorf("decode json: %w", err)
+		}
+		for key, text := range phrases {
+			err = checkPhrase(key, text)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return phrases, nil
+	}
+	return nil, fmt.Errorf("%s: unsupported phrases file, use .csv or .json", path)
+}
+
+// ParsePhrasesCSV reads phrases with a key,text header, rejecting unknown and duplicate keys
+func ParsePhrasesCSV(r io.Reader) (Phrases, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 2
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	if len(records) == 0 || !strings.EqualFold(records[0][0], "key") || !strings.EqualFold(records[0][1], "text") {
+		return nil, fmt.Errorf("read csv: missing key,text header")
+	}
+
+	phrases := Phrases{}
+	for i, record := range records[1:] {
+		key := strings.ToLower(strings.TrimSpace(record[0]))
+		err = checkPhrase(key, record[1])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		_, ok := phrases[key]
+		if ok {
+			return nil, fmt.Errorf("row %d: duplicate phrase %s", i+2, key)
+		}
+		phrases[key] = record[1]
+	}
+	return phrases, nil
+}
+
+// checkPhrase reports a key that is not a phrase and bad placeholders of a cast phrase
+func checkPhrase(key string, text string) error {
+	_, ok := DefaultPhrases[key]
+	if !ok {
+		return fmt.Errorf("unknown phrase %q, use %s", key, strings.Join(PhraseKeys(), ", "))
+	}
+	for _, keys := range castPhrases {
+		if key != keys[0] && key != keys[1] {
+			continue
+		}
+		err := CheckText(text)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// fill replaces each {name} of the phrase of key with its value
+func (p Phrases) fill(key string, values map[string]string) string {
+	text := p.Get(key)
+	for name, value := range values {
+		text = strings.ReplaceAll(text, "{"+name+"}", value)
+	}
+	return text
+}
@@ -0,0 +1,79 @@
+package item
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xackery/wbc3-cli/wbc3/strtab"
+)
+
+func TestPhrases(t *testing.T) {
+	german := Phrases{
+		PhraseCastOnHit:        "Wirkt {spell} ({chance}% Chance pro Treffer)",
+		PhraseCastOnUseLevel:   "Wirkt {spell} Stufe {level} bei Benutzung",
+		PhraseUnknownHeroSkill: "Heldenfähigkeit {data}",
+		PhraseStr:              "STÄ",
+		PhraseHeavilyCursed:    "Ja (schwer)",
+	}
+	r := &Renderer{Text: strtab.Table{"SPELL_NAME_12": "Feuerball"}, Phrases: german}
+	tests := []struct {
+		power RecordPower
+		want  string
+	}{
+		{RecordPower{Type: PowerCastSpell, Data: 12, Chance: 5, HasChance: true}, "Wirkt Feuerball (5% Chance pro Treffer)"},
+		{RecordPower{Type: PowerCastSpell, Data: 12, Level: 2}, "Wirkt Feuerball Stufe 2 bei Benutzung"},
+		{RecordPower{Type: PowerCastSpell, Data: 12, HasChance: true}, "Casts Feuerball as a passive aura"},
+		{RecordPower{Type: PowerHeroSkill, Data: 999, Level: 1}, "Heldenfähigkeit 999"},
+	}
+	for _, tt := range tests {
+		got, err := r.Power(tt.power)
+		if err != nil || got != tt.want {
+			t.Errorf("Power(%+v) = %q, %v, want %q", tt.power, got, err, tt.want)
+		}
+	}
+
+	record := Record{Req: Requirement{Str: 10, Dex: 5}, Cursed: true, HeavyCursed: true}
+	if got := r.Requirements(record); got != "10 STÄ, 5 DEX" {
+		t.Errorf("Requirements = %q, want %q", got, "10 STÄ, 5 DEX")
+	}
+	if got := r.Cursed(record); got != "Ja (schwer)" {
+		t.Errorf("Cursed = %q, want %q", got, "Ja (schwer)")
+	}
+}
+
+func TestParsePhrasesCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Phrases
+		wantErr string
+	}{
+		{"phrases", "key,text\n# comment\ncursed,Ja\nCast On Use,Wirkt {spell}\n", Phrases{PhraseCursed: "Ja", PhraseCastOnUse: "Wirkt {spell}"}, ""},
+		{"no header", "cursed,Ja\n", nil, "missing key,text header"},
+		{"unknown key", "key,text\ncursd,Ja\n", nil, `row 2: unknown phrase "cursd"`},
+		{"duplicate key", "key,text\ncursed,Ja\nCURSED,Nein\n", nil, "row 3: duplicate phrase cursed"},
+		{"bad placeholder", "key,text\ncast on use,Wirkt {zauber}\n", nil, "unknown placeholder {zauber}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePhrasesCSV(strings.NewReader(tt.in))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for key, text := range tt.want {
+				if got[key] != text {
+					t.Errorf("%s = %q, want %q", key, got[key], text)
+				}
+			}
+		})
+	}
+}
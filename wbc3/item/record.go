@@ -0,0 +1,371 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PowerType is the type attribute of a power as written, trimmed, such as "Cast Spell" or "HP Regen".
+// item.xml is not consistent about case, so compare types with Is
+type PowerType string
+
+const (
+	PowerCastSpell PowerType = "Cast Spell"
+	PowerHeroSkill PowerType = "Hero Skill"
+	PowerSpeed     PowerType = "Speed"
+)
+
+// Key returns the type lower cased with single spaces, the form types are matched by
+func (t PowerType) Key() string {
+	return strings.ToLower(strings.Join(strings.Fields(string(t)), " "))
+}
+
+// Is reports if t and other are the same type, ignoring case and spacing
+func (t PowerType) Is(other PowerType) bool {
+	return t.Key() == other.Key()
+}
+
+// Rarity is the rarity attribute of an item, one of Rarities when known
+type Rarity string
+
+const (
+	RarityCommon   Rarity = "Common"
+	RarityUncommon Rarity = "Uncommon"
+	RarityRare     Rarity = "Rare"
+	RarityArtifact Rarity = "Artifact"
+)
+
+// Rarities is every rarity the tool knows
+var Rarities = []Rarity{RarityCommon, RarityUncommon, RarityRare, RarityArtifact}
+
+// ParseRarity returns the known rarity matching value ignoring case, or value trimmed and false
+func ParseRarity(value string) (Rarity, bool) {
+	value = strings.TrimSpace(value)
+	for _, rarity := range Rarities {
+		if strings.EqualFold(value, string(rarity)) {
+			return rarity, true
+		}
+	}
+	return Rarity(value), value == ""
+}
+
+// Level is the level attribute of an item, one of Levels when known
+type Level string
+
+const (
+	LevelMinor   Level = "Minor"
+	LevelLesser  Level = "Lesser"
+	LevelGreater Level = "Greater"
+	LevelMajor   Level = "Major"
+)
+
+// Levels is every level the tool knows
+var Levels = []Level{LevelMinor, LevelLesser, LevelGreater, LevelMajor}
+
+// ParseLevel returns the known level matching value ignoring case, or value trimmed and false
+func ParseLevel(value string) (Level, bool) {
+	value = strings.TrimSpace(value)
+	for _, level := range Levels {
+		if strings.EqualFold(value, string(level)) {
+			return level, true
+		}
+	}
+	return Level(value), value == ""
+}
+
+// Record is the typed form of an Item, with every numeric field parsed
+type Record struct {
+	ID          int
+	Name        string
+	Description string
+	Powers      []RecordPower
+	IconRow     int
+	IconCol     int
+	Value       int
+	Level       Level
+	Rarity      Rarity
+	// KnownLevel and KnownRarity are false when the attribute is set to a value outside Levels or Rarities
+	KnownLevel  bool
+	KnownRarity bool
+	Slot        Slot
+	// Pickup is the pickup sound the slot was derived from
+	Pickup string
+	// KnownSlot is false when the pickup sound is missing or not in the slot map
+	KnownSlot   bool
+	Durability  int
+	Req         Requirement
+	Cursed      bool
+	HeavyCursed bool
+	// RawCurse is the first Curse as written in item.xml, Cursed is set by any of them
+	RawCurse Curse
+	Sounds   []RecordSound
+	// Invalid is the raw value of every field that failed to parse, keyed by field such as Data.value.
+	// The typed field is left as zero
+	Invalid map[string]string
+}
+
+// RecordSound is the sound set of an item
+type RecordSound struct {
+	Damage string `json:"damage,omitempty"`
+	Pickup string `json:"pickup,omitempty"`
+	Skin   string `json:"skin,omitempty"`
+}
+
+// RecordPower is the typed form of a Power
+type RecordPower struct {
+	ID   int
+	Type PowerType
+	// Data is the spell or hero skill id of a Cast Spell or Hero Skill power, whose data must be a whole number,
+	// and Value without its fraction for other types
+	Data int
+	// Value is the data attribute as written, which may have a fraction such as 1.5
+	Value  float64
+	Level  int
+	Chance int
+	// HasChance is set when the chance attribute was present
+	HasChance bool
+	// Raw is the power as written in item.xml
+	Raw Power
+}
+
+// CastMode is when a Cast Spell power casts its spell
+type CastMode string
+
+const (
+	// CastOnHit casts with a chance per hit, the power has a chance above 0
+	CastOnHit CastMode = "hit"
+	// CastOnUse casts when the item is used, the power has no chance
+	CastOnUse CastMode = "use"
+	// CastAura is always active, the power has a chance of 0
+	CastAura CastMode = "aura"
+)
+
+// CastMode returns when a Cast Spell power casts, empty for other power types
+func (p RecordPower) CastMode() CastMode {
+	if !p.Type.Is(PowerCastSpell) {
+		return ""
+	}
+	if !p.HasChance {
+		return CastOnUse
+	}
+	if p.Chance <= 0 {
+		return CastAura
+	}
+	return CastOnHit
+}
+
+// Requirement is the typed form of Req
+type Requirement struct {
+	Str int `json:"str"`
+	Int int `json:"int"`
+	Dex int `json:"dex"`
+	Cha int `json:"cha"`
+}
+
+// FieldError is a field of an item that failed to parse
+type FieldError struct {
+	// Item is the index of the item in Items.Items, set by Decode
+	Item   int
+	ItemID string
+	Field  string
+	Value  string
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("item %s %s %q: %s", e.ItemID, e.Field, e.Value, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors is every field that failed to parse during Decode
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	lines := []string{}
+	for _, fieldErr := range e {
+		lines = append(lines, fieldErr.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Decode converts every item into a Record, using slots to tell the slot of each item (DefaultSlotMap when nil).
+// All records are returned even on error, with bad fields left as zero, and the error is a FieldErrors
+func Decode(items *Items, slots SlotMap) ([]Record, error) {
+	if slots == nil {
+		slots = DefaultSlotMap()
+	}
+	records := []Record{}
+	errs := FieldErrors{}
+	for i := range items.Items {
+		record, err := items.Items[i].Record(slots)
+		if err != nil {
+			for _, fieldErr := range err.(FieldErrors) {
+				fieldErr.Item = i
+				errs = append(errs, fieldErr)
+			}
+		}
+		records = append(records, record)
+	}
+	if len(errs) > 0 {
+		return records, errs
+	}
+	return records, nil
+}
+
+// Record converts the item into its typed form, the error is a FieldErrors
+func (item *Item) Record(slots SlotMap) (Record, error) {
+	d := &fieldDecoder{itemID: item.ID}
+	slot, knownSlot := item.Slot(slots)
+	level, knownLevel := ParseLevel(item.Data.Level)
+	rarity, knownRarity := ParseRarity(item.Data.Rarity)
+	record := Record{
+		ID:          d.int("id", item.ID),
+		Name:        strings.TrimSpace(item.Name),
+		Description: strings.TrimSpace(item.Description),
+		IconRow:     d.int("Image.iconrow", item.Image.Iconrow),
+		IconCol:     d.int("Image.iconcol", item.Image.Iconcol),
+		Value:       d.int("Data.value", item.Data.Value),
+		Level:       level,
+		Rarity:      rarity,
+		KnownLevel:  knownLevel,
+		KnownRarity: knownRarity,
+		Slot:        slot,
+		Pickup:      item.Pickup(),
+		KnownSlot:   knownSlot,
+		Durability:  d.int("Durability", item.Durability),
+		Req: Requirement{
+			Str: d.int("Req.str", item.Req.Str),
+			Int: d.int("Req.int", item.Req.Int),
+			Dex: d.int("Req.dex", item.Req.Dex),
+			Cha: d.int("Req.cha", item.Req.Cha),
+		},
+	}
+
+	for i, power := range item.Power {
+		field := fmt.Sprintf("Power[%d].", i)
+		powerType := PowerType(strings.TrimSpace(power.Type))
+		var value float64
+		var data int
+		if powerType.Is(PowerCastSpell) || powerType.Is(PowerHeroSkill) {
+			// an id with a fraction would silently name another spell or skill
+			data = d.int(field+"data", power.Data)
+			value = float64(data)
+		} else {
+			value = d.float(field+"data", power.Data)
+			data = int(value)
+		}
+		record.Powers = append(record.Powers, RecordPower{
+			ID:        d.int(field+"id", power.ID),
+			Type:      powerType,
+			Data:      data,
+			Value:     value,
+			Level:     d.int(field+"level", power.Level),
+			Chance:    d.int(field+"chance", power.Chance),
+			HasChance: strings.TrimSpace(power.Chance) != "",
+			Raw:       power,
+		})
+	}
+
+	for _, sound := range item.Sound {
+		record.Sounds = append(record.Sounds, RecordSound{
+			Damage: strings.TrimSpace(sound.Damage),
+			Pickup: strings.TrimSpace(sound.Pickup),
+			Skin:   strings.TrimSpace(sound.Skin),
+		})
+	}
+
+	if len(item.Curse) > 0 {
+		record.RawCurse = item.Curse[0]
+	}
+	for i, curse := range item.Curse {
+		field := fmt.Sprintf("Curse[%d].", i)
+		if d.int(field+"data", curse.Data) != 1 || record.Cursed {
+			continue
+		}
+		record.Cursed = true
+		record.HeavyCursed = d.int(field+"heavilycursed", curse.Heavilycursed) == 1
+	}
+
+	if len(d.errs) > 0 {
+		record.Invalid = make(map[string]string)
+		for _, fieldErr := range d.errs {
+			record.Invalid[fieldErr.Field] = fieldErr.Value
+		}
+		return record, d.errs
+	}
+	return record, nil
+}
+
+// UnknownSlots returns every record whose pickup sound is missing or not in the slot map
+func UnknownSlots(records []Record) []Record {
+	unknown := []Record{}
+	for _, record := range records {
+		if record.KnownSlot {
+			continue
+		}
+		unknown = append(unknown, record)
+	}
+	return unknown
+}
+
+// errors of a FieldError
+var (
+	ErrNotNumber  = errors.New("not a number")
+	ErrNotWhole   = errors.New("not a whole number")
+	ErrOutOfRange = errors.New("out of the 32 bit range the game stores numbers in")
+)
+
+// fieldDecoder parses fields of one item, collecting every failure
+type fieldDecoder struct {
+	itemID string
+	errs   FieldErrors
+}
+
+// int parses value as a 32 bit integer, an empty value is zero
+func (d *fieldDecoder) int(field string, value string) int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	num, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		_, floatErr := strconv.ParseFloat(value, 64)
+		switch {
+		case errors.Is(err, strconv.ErrRange):
+			err = ErrOutOfRange
+		case floatErr == nil:
+			err = ErrNotWhole
+		default:
+			err = ErrNotNumber
+		}
+		d.errs = append(d.errs, &FieldError{ItemID: d.itemID, Field: field, Value: value, Err: err})
+		return 0
+	}
+	return int(num)
+}
+
+// float parses value as a number with an optional fraction within the 32 bit range, an empty value is zero
+func (d *fieldDecoder) float(field string, value string) float64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	num, err := strconv.ParseFloat(value, 64)
+	switch {
+	case errors.Is(err, strconv.ErrRange) || math.IsInf(num, 0) || num < math.MinInt32 || num > math.MaxInt32:
+		err = ErrOutOfRange
+	case err != nil || math.IsNaN(num):
+		err = ErrNotNumber
+	}
+	if err != nil {
+		d.errs = append(d.errs, &FieldError{ItemID: d.itemID, Field: field, Value: value, Err: err})
+		return 0
+	}
+	return num
+}
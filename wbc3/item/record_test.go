@@ -0,0 +1,96 @@
+package item
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPowerTypeAsWritten(t *testing.T) {
+	items, err := Parse(strings.NewReader(`<Items><Item id="1">
+		<Power type=" HP Regen " data="1.55"/>
+		<Power type="cast  SPELL" data="1" chance="5"/>
+		<Data rarity="rare" level="GREATER"/>
+	</Item></Items>`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	record, err := items.Items[0].Record(nil)
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	if record.Powers[0].Type != "HP Regen" {
+		t.Errorf("Type = %q, want it as written and trimmed", record.Powers[0].Type)
+	}
+	r := &Renderer{Formats: NewPowerFormats()}
+	text, err := r.Power(record.Powers[0])
+	if err != nil || text != "+1.55 HP Regen" {
+		t.Errorf("Power = %q, %v, want +1.55 HP Regen", text, err)
+	}
+	if !record.Powers[1].Type.Is(PowerCastSpell) || record.Powers[1].CastMode() != CastOnHit {
+		t.Errorf("%q is not matched as %q", record.Powers[1].Type, PowerCastSpell)
+	}
+	if record.Rarity != RarityRare || !record.KnownRarity || record.Level != LevelGreater || !record.KnownLevel {
+		t.Errorf("Rarity, Level = %q %v, %q %v, want Rare and Greater", record.Rarity, record.KnownRarity, record.Level, record.KnownLevel)
+	}
+}
+
+func TestParseRarityAndLevel(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Rarity
+		known bool
+	}{
+		{"Common", RarityCommon, true},
+		{" uncommon ", RarityUncommon, true},
+		{"", "", true},
+		{"Legendary", "Legendary", false},
+	}
+	for _, tt := range tests {
+		got, known := ParseRarity(tt.value)
+		if got != tt.want || known != tt.known {
+			t.Errorf("ParseRarity(%q) = %q, %v, want %q, %v", tt.value, got, known, tt.want, tt.known)
+		}
+	}
+
+	level, known := ParseLevel("epic")
+	if level != "epic" || known {
+		t.Errorf("ParseLevel(epic) = %q, %v, want epic, false", level, known)
+	}
+}
+
+func TestPowerDataErrors(t *testing.T) {
+	tests := []struct {
+		power string
+		err   error
+		data  int
+		value float64
+	}{
+		{`<Power type="Cast Spell" data="12"/>`, nil, 12, 12},
+		{`<Power type="cast spell" data="1.7"/>`, ErrNotWhole, 0, 0},
+		{`<Power type="Hero Skill" data="99999999999"/>`, ErrOutOfRange, 0, 0},
+		{`<Power type="Hero Skill" data="x"/>`, ErrNotNumber, 0, 0},
+		{`<Power type="HP Regen" data="1.7"/>`, nil, 1, 1.7},
+		{`<Power type="Armor" data="1e30"/>`, ErrOutOfRange, 0, 0},
+		{`<Power type="Armor" data="NaN"/>`, ErrNotNumber, 0, 0},
+	}
+	for _, tt := range tests {
+		items, err := Parse(strings.NewReader(`<Items><Item id="1">` + tt.power + `</Item></Items>`))
+		if err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+		record, err := items.Items[0].Record(nil)
+		var got error
+		fieldErrs, _ := err.(FieldErrors)
+		if len(fieldErrs) > 0 {
+			got = fieldErrs[0].Err
+		}
+		if !errors.Is(got, tt.err) {
+			t.Errorf("%s: Record error %v, want %v", tt.power, err, tt.err)
+		}
+		if record.Powers[0].Data != tt.data || record.Powers[0].Value != tt.value {
+			t.Errorf("%s: Data, Value = %d, %v, want %d, %v", tt.power, record.Powers[0].Data, record.Powers[0].Value, tt.data, tt.value)
+		}
+	}
+}
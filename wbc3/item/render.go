@@ -0,0 +1,147 @@
+package item
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xackery/wbc3-cli/wbc3/skill"
+	"github.com/xackery/wbc3-cli/wbc3/strtab"
+)
+
+// MaxPowers is how many powers the game supports per item, items with more are still exported in full
+const MaxPowers = 4
+
+// ErrUnknownSpell is returned for a Cast Spell power whose spell is not in the string tables
+var ErrUnknownSpell = errors.New("spell not found")
+
+// Renderer turns items into human readable text
+type Renderer struct {
+	// Text resolves spell names, usually a strtab.Catalog of the game's language folder
+	Text strtab.Lookup
+	// SkillKey is the string table key prefix of a hero skill name, followed by the skill id.
+	// Hero skill names are looked up in SkillText, or Text when nil, only when it is set,
+	// as the key of the stock tables is not known. Names not found come from Skills
+	SkillKey  string
+	SkillText strtab.Lookup
+	// Skills is the hero skill table names come from, skill.Default() when nil
+	Skills *skill.Table
+	// Formats renders each power type, DefaultPowerFormats() when nil
+	Formats *PowerFormats
+	// Phrases replaces the English texts written around names and numbers, DefaultPhrases for keys it does not have
+	Phrases Phrases
+
+	// fromSkills is every hero skill id whose name came from Skills instead of Text
+	fromSkills map[int]bool
+}
+
+// Power renders a single power with the func registered for its type, such as "+2 Armor" or "Casts Fireball (5% chance per hit)".
+// Types without one are rendered as "+data Type", see KnownPower
+func (r *Renderer) Power(power RecordPower) (string, error) {
+	fn, ok := r.formats().Lookup(power.Type)
+	if !ok {
+		return FormatNumber(power.Value, true, -1) + " " + string(power.Type), nil
+	}
+	return fn(r, power)
+}
+
+// KnownPower reports if a power type has a func registered in Formats
+func (r *Renderer) KnownPower(powerType PowerType) bool {
+	_, ok := r.formats().Lookup(powerType)
+	return ok
+}
+
+func (r *Renderer) formats() *PowerFormats {
+	if r.Formats == nil {
+		r.Formats = DefaultPowerFormats()
+	}
+	return r.Formats
+}
+
+// SpellName returns the name of a spell from the string tables
+func (r *Renderer) SpellName(spellID int) (string, bool) {
+	if r.Text == nil {
+		return "", false
+	}
+	return r.Text.Lookup(fmt.Sprintf("%s%d", SpellNameKey, spellID))
+}
+
+// HeroSkillName returns the name of a hero skill from the string tables when SkillKey is set, or from the skill table
+func (r *Renderer) HeroSkillName(skillID int) (string, bool) {
+	text := r.SkillText
+	if text == nil {
+		text = r.Text
+	}
+	if r.SkillKey != "" && text != nil {
+		name, ok := text.Lookup(fmt.Sprintf("%s%d", r.SkillKey, skillID))
+		if ok {
+			return name, true
+		}
+	}
+	if r.Skills == nil {
+		r.Skills = skill.Default()
+	}
+	name, ok := r.Skills.Name(skillID)
+	if ok {
+		if r.fromSkills == nil {
+			r.fromSkills = make(map[int]bool)
+		}
+		r.fromSkills[skillID] = true
+	}
+	return name, ok
+}
+
+// SkillTableNames returns every hero skill id whose name came from the skill table instead of the string tables, sorted.
+// The built in table is English, so for other languages these are English
+func (r *Renderer) SkillTableNames() []int {
+	ids := []int{}
+	for id := range r.fromSkills {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Requirements renders the stat requirements of an item, such as "10 STR, 5 DEX"
+func (r *Renderer) Requirements(record Record) string {
+	req := []string{}
+	stats := []struct {
+		key   string
+		value int
+	}{
+		{PhraseStr, record.Req.Str},
+		{PhraseInt, record.Req.Int},
+		{PhraseDex, record.Req.Dex},
+		{PhraseCha, record.Req.Cha},
+	}
+	for _, stat := range stats {
+		if stat.value == 0 {
+			continue
+		}
+		req = append(req, r.Phrases.fill(PhraseRequirement, map[string]string{"value": strconv.Itoa(stat.value), "stat": r.Phrases.Get(stat.key)}))
+	}
+	return strings.Join(req, r.Phrases.Get(PhraseRequirementJoin))
+}
+
+// Cursed renders the curse state of an item: No, Yes or Yes (Heavy)
+func (r *Renderer) Cursed(record Record) string {
+	if !record.Cursed {
+		return r.Phrases.Get(PhraseNotCursed)
+	}
+	if record.HeavyCursed {
+		return r.Phrases.Get(PhraseHeavilyCursed)
+	}
+	return r.Phrases.Get(PhraseCursed)
+}
+
+// unknownSpell is the text of a spell id without a name, such as "Spell 12"
+func (r *Renderer) unknownSpell(spellID int) string {
+	return r.Phrases.fill(PhraseUnknownSpell, map[string]string{"data": strconv.Itoa(spellID)})
+}
+
+// unknownHeroSkill is the text of a hero skill id without a name, such as "Hero Skill 12"
+func (r *Renderer) unknownHeroSkill(skillID int) string {
+	return r.Phrases.fill(PhraseUnknownHeroSkill, map[string]string{"data": strconv.Itoa(skillID)})
+}
@@ -0,0 +1,34 @@
+package item
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xackery/wbc3-cli/wbc3/strtab"
+)
+
+func TestHeroSkillName(t *testing.T) {
+	text := strtab.Table{"SKILL_NAME_34": "Mächtiger Schlag", "SPELL_NAME_34": "Feuerball"}
+	tests := []struct {
+		name      string
+		renderer  *Renderer
+		want      string
+		fromTable []int
+	}{
+		{"no key", &Renderer{Text: text}, "Mighty Blow", []int{34}},
+		{"key in text", &Renderer{Text: text, SkillKey: "SKILL_NAME_"}, "Mächtiger Schlag", []int{}},
+		{"key in skill text", &Renderer{Text: strtab.Table{}, SkillText: text, SkillKey: "SKILL_NAME_"}, "Mächtiger Schlag", []int{}},
+		{"key missing", &Renderer{Text: text, SkillKey: "HERO_SKILL_"}, "Mighty Blow", []int{34}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.renderer.HeroSkillName(34)
+			if !ok || got != tt.want {
+				t.Errorf("HeroSkillName(34) = %q, %v, want %q", got, ok, tt.want)
+			}
+			if !reflect.DeepEqual(tt.renderer.SkillTableNames(), tt.fromTable) {
+				t.Errorf("SkillTableNames = %v, want %v", tt.renderer.SkillTableNames(), tt.fromTable)
+			}
+		})
+	}
+}
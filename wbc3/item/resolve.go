@@ -0,0 +1,145 @@
+package item
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Resolved is an item with every name looked up and every power rendered, ready to be exported
+type Resolved struct {
+	ID               int             `json:"id"`
+	Name             string          `json:"name"`
+	Description      string          `json:"description,omitempty"`
+	Slot             Slot            `json:"slot"`
+	Rarity           Rarity          `json:"rarity"`
+	Level            Level           `json:"level"`
+	Value            int             `json:"value"`
+	Durability       int             `json:"durability"`
+	IconRow          int             `json:"iconRow"`
+	IconCol          int             `json:"iconCol"`
+	Powers           []ResolvedPower `json:"powers"`
+	Requirements     Requirement     `json:"requirements"`
+	RequirementsText string          `json:"requirementsText"`
+	Cursed           bool            `json:"cursed"`
+	HeavyCursed      bool            `json:"heavyCursed"`
+	CursedText       string          `json:"cursedText"`
+	Sounds           []RecordSound   `json:"sounds"`
+	// RawCurse is the first Curse as written in item.xml
+	RawCurse Curse `json:"-"`
+	// Invalid is the raw value of every field that is not a number, such as {"Data.value": "abc"}, the field is 0 then
+	Invalid map[string]string `json:"invalid,omitempty"`
+}
+
+// ResolvedPower is a power with its spell or hero skill name looked up and its text rendered
+type ResolvedPower struct {
+	Type          PowerType `json:"type"`
+	Data          int       `json:"data"`
+	Value         float64   `json:"value"`
+	Level         int       `json:"level"`
+	Chance        int       `json:"chance,omitempty"`
+	SpellName     string    `json:"spellName,omitempty"`
+	Cast          CastMode  `json:"cast,omitempty"`
+	HeroSkillName string    `json:"heroSkillName,omitempty"`
+	Text          string    `json:"text"`
+	// Error is why the power could not be rendered, Text is a placeholder then
+	Error string `json:"error,omitempty"`
+	// Unrecognized is set when no format is registered for the type, Text is "+data Type" then
+	Unrecognized bool `json:"unrecognized,omitempty"`
+	// Raw is the power as written in item.xml
+	Raw Power `json:"raw"`
+}
+
+// PowerError is a power of an item that failed to render
+type PowerError struct {
+	// Power is the index of the power in the item
+	Power int
+	Err   error
+}
+
+func (e *PowerError) Error() string {
+	return fmt.Sprintf("Power[%d]: %s", e.Power, e.Err)
+}
+
+func (e *PowerError) Unwrap() error {
+	return e.Err
+}
+
+// PowerErrors is every power of an item that failed to render
+type PowerErrors []*PowerError
+
+func (e PowerErrors) Error() string {
+	lines := []string{}
+	for _, powerErr := range e {
+		lines = append(lines, powerErr.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Resolve looks up every name of a record and renders its powers.
+// A power that fails is rendered as a placeholder with its error, and the error returned is a PowerErrors
+func (r *Renderer) Resolve(record Record) (Resolved, error) {
+	resolved := Resolved{
+		ID:               record.ID,
+		Name:             record.Name,
+		Description:      record.Description,
+		Slot:             record.Slot,
+		Rarity:           record.Rarity,
+		Level:            record.Level,
+		Value:            record.Value,
+		Durability:       record.Durability,
+		IconRow:          record.IconRow,
+		IconCol:          record.IconCol,
+		Powers:           []ResolvedPower{},
+		Requirements:     record.Req,
+		RequirementsText: r.Requirements(record),
+		Cursed:           record.Cursed,
+		HeavyCursed:      record.HeavyCursed,
+		CursedText:       r.Cursed(record),
+		Sounds:           append([]RecordSound{}, record.Sounds...),
+		RawCurse:         record.RawCurse,
+		Invalid:          record.Invalid,
+	}
+
+	errs := PowerErrors{}
+	for i, power := range record.Powers {
+		text, err := r.Power(power)
+		if err != nil {
+			errs = append(errs, &PowerError{Power: i, Err: err})
+			text = "[" + err.Error() + "]"
+		}
+		rp := ResolvedPower{
+			Type:   power.Type,
+			Data:   power.Data,
+			Value:  power.Value,
+			Level:  power.Level,
+			Chance: power.Chance,
+			Text:   text,
+			Raw:    power.Raw,
+		}
+		switch {
+		case power.Type.Is(PowerCastSpell):
+			rp.SpellName, _ = r.SpellName(power.Data)
+			rp.Cast = power.CastMode()
+		case power.Type.Is(PowerHeroSkill):
+			rp.HeroSkillName, _ = r.HeroSkillName(power.Data)
+		}
+		if err != nil {
+			rp.Error = err.Error()
+		}
+		rp.Unrecognized = !r.KnownPower(power.Type)
+		resolved.Powers = append(resolved.Powers, rp)
+	}
+	if len(errs) > 0 {
+		return resolved, errs
+	}
+	return resolved, nil
+}
+
+// WriteJSON writes items as an indented json array
+func WriteJSON(w io.Writer, items []Resolved) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(items)
+}
@@ -0,0 +1,171 @@
+package item
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//go:embed slots.csv
+var defaultSlots string
+
+// Slot is the equipment slot an item is worn in
+type Slot string
+
+const (
+	SlotBody    Slot = "Body"
+	SlotFeet    Slot = "Feet"
+	SlotFinger  Slot = "Finger"
+	SlotHand    Slot = "Hand"
+	SlotHead    Slot = "Head"
+	SlotMisc    Slot = "Misc"
+	SlotNeck    Slot = "Neck"
+	SlotOffhand Slot = "Offhand"
+)
+
+// Slots is every known slot, in the order they are written out
+var Slots = []Slot{SlotBody, SlotFeet, SlotFinger, SlotHand, SlotHead, SlotMisc, SlotNeck, SlotOffhand}
+
+// SlotMap maps a pickup sound to the slot it is worn in
+type SlotMap map[string]Slot
+
+// DefaultSlotMap returns the pickup sounds used by the game's own items
+func DefaultSlotMap() SlotMap {
+	m, err := ParseSlotMapCSV(strings.NewReader(defaultSlots))
+	if err != nil {
+		panic(fmt.Sprintf("embedded slots.csv: %s", err))
+	}
+	return m
+}
+
+// LoadSlotMap reads a slot map from a .csv file with a pickup,slot header or a .json object of pickup to slot
+func LoadSlotMap(path string) (SlotMap, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseSlotMapCSV(r)
+	case ".json":
+		m := SlotMap{}
+		err = json.NewDecoder(r).Decode(&m)
+		if err != nil {
+			return nil, fmt.Errorf("decode json: %w", err)
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("%s: unsupported slot file, use .csv or .json", path)
+}
+
+// ParseSlotMapCSV reads a slot map with a pickup,slot header
+func ParseSlotMapCSV(r io.Reader) (SlotMap, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 2
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	if len(records) == 0 || !strings.EqualFold(records[0][0], "pickup") || !strings.EqualFold(records[0][1], "slot") {
+		return nil, fmt.Errorf("read csv: missing pickup,slot header")
+	}
+
+	m := SlotMap{}
+	for i, record := range records[1:] {
+		pickup := strings.TrimSpace(record[0])
+		_, ok := m[pickup]
+		if ok {
+			return nil, fmt.Errorf("row %d: duplicate pickup %s", i+2, pickup)
+		}
+		m[pickup] = Slot(strings.TrimSpace(record[1]))
+	}
+	return m, nil
+}
+
+// Merge adds every entry of other, replacing pickups m already has
+func (m SlotMap) Merge(other SlotMap) {
+	for pickup, slot := range other {
+		m[pickup] = slot
+	}
+}
+
+// Slot maps a pickup sound to a slot, unknown sounds are returned with an UNK suffix and false
+func (m SlotMap) Slot(pickup string) (Slot, bool) {
+	slot, ok := m[pickup]
+	if !ok {
+		return Slot(pickup + "UNK"), false
+	}
+	return slot, true
+}
+
+// Slots returns the slots tables are written in: the built in Slots order, then any extra slot of the map sorted
+func (m SlotMap) Slots() []Slot {
+	slots := append([]Slot{}, Slots...)
+	extra := []Slot{}
+	seen := make(map[Slot]bool)
+	for _, slot := range Slots {
+		seen[slot] = true
+	}
+	for _, slot := range m {
+		if seen[slot] {
+			continue
+		}
+		seen[slot] = true
+		extra = append(extra, slot)
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i] < extra[j] })
+	return append(slots, extra...)
+}
+
+// Pickup returns the first pickup sound of the item
+func (item *Item) Pickup() string {
+	for _, sound := range item.Sound {
+		if sound.Pickup == "" {
+			continue
+		}
+		return sound.Pickup
+	}
+	return ""
+}
+
+// Slot returns the equipment slot of the item based on its pickup sound, DefaultSlotMap is used when slots is nil
+func (item *Item) Slot(slots SlotMap) (Slot, bool) {
+	pickup := item.Pickup()
+	if pickup == "" {
+		return "", false
+	}
+	if slots == nil {
+		slots = DefaultSlotMap()
+	}
+	return slots.Slot(pickup)
+}
+
+// tableSlots returns slots followed by every slot of items missing from it, in item order,
+// so tables written per slot also hold items of an unknown pickup sound
+func tableSlots(items []Resolved, slots []Slot) []Slot {
+	order := append([]Slot{}, slots...)
+	for _, item := range items {
+		if !containsSlot(order, item.Slot) {
+			order = append(order, item.Slot)
+		}
+	}
+	return order
+}
+
+func containsSlot(slots []Slot, slot Slot) bool {
+	for _, s := range slots {
+		if s == slot {
+			return true
+		}
+	}
+	return false
+}
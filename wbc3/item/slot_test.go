@@ -0,0 +1,99 @@
+package item
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSlotMapCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    SlotMap
+		wantErr string
+	}{
+		{"entries", "pickup,slot\n# mod sounds\n Lute , Hand \nRing,Neck\n", SlotMap{"Lute": "Hand", "Ring": "Neck"}, ""},
+		{"header only", "Pickup,Slot\n", SlotMap{}, ""},
+		{"no header", "Lute,Hand\n", nil, "missing pickup,slot header"},
+		{"extra column", "pickup,slot\nLute,Hand,Back\n", nil, "read csv"},
+		{"duplicate pickup", "pickup,slot\nLute,Hand\nLute,Back\n", nil, "row 3: duplicate pickup Lute"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSlotMapCSV(strings.NewReader(tt.in))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSlotMap(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		file    string
+		content string
+		want    SlotMap
+		wantErr string
+	}{
+		{"slots.csv", "pickup,slot\nLute,Hand\n", SlotMap{"Lute": "Hand"}, ""},
+		{"slots.json", `{"Lute": "Hand", "Ring": "Neck"}`, SlotMap{"Lute": "Hand", "Ring": "Neck"}, ""},
+		{"bad.json", `["Lute"]`, nil, "decode json"},
+		{"slots.txt", "Lute=Hand", nil, "unsupported slot file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+			err := os.WriteFile(path, []byte(tt.content), 0o644)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadSlotMap(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlotMapMerge(t *testing.T) {
+	slots := DefaultSlotMap()
+	slots.Merge(SlotMap{"Ring": "Neck", "Lute": "Hand"})
+	tests := []struct {
+		pickup string
+		want   Slot
+		known  bool
+	}{
+		{"Ring", "Neck", true},
+		{"Lute", "Hand", true},
+		{"Rod", "Hand", true},
+		{"Drum", "DrumUNK", false},
+	}
+	for _, tt := range tests {
+		got, ok := slots.Slot(tt.pickup)
+		if got != tt.want || ok != tt.known {
+			t.Errorf("Slot(%q) = %q, %v, want %q, %v", tt.pickup, got, ok, tt.want, tt.known)
+		}
+	}
+}
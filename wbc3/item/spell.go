@@ -0,0 +1,24 @@
+package item
+
+import (
+	"strconv"
+
+	"github.com/xackery/wbc3-cli/wbc3/strtab"
+)
+
+// SpellNameKey is the Spells.txt key prefix of a spell name, followed by the spell number
+const SpellNameKey = "SPELL_NAME_"
+
+// Spells returns the spell names of a Spells.txt table, keyed by spell number.
+// Plural and singular variants such as [SPELL_NAME_PLURAL_12] are skipped
+func Spells(table strtab.Table) map[int]string {
+	spells := make(map[int]string)
+	for suffix, name := range table.Prefixed(SpellNameKey) {
+		spellNumber, err := strconv.Atoi(suffix)
+		if err != nil {
+			continue
+		}
+		spells[spellNumber] = name
+	}
+	return spells
+}
@@ -0,0 +1,96 @@
+package item
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// TemplateData is what an export template is executed with
+type TemplateData struct {
+	Items []Resolved
+	// Slots is every slot in export order, use BySlot for its items
+	Slots []Slot
+}
+
+// BySlot returns the items of a slot, in item.xml order
+func (d TemplateData) BySlot(slot Slot) []Resolved {
+	items := []Resolved{}
+	for _, item := range d.Items {
+		if item.Slot == slot {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// TemplateFuncs returns the helpers export templates can call:
+//
+//	spell ID            spell name, "Spell ID" when missing
+//	skill ID            hero skill name, "Hero Skill ID" when missing
+//	sign N              N with a + when above zero, such as +5 or -5
+//	number N DECIMALS   N rounded to DECIMALS digits, -1 for every digit needed
+//	join LIST SEP       strings.Join, texts LIST returns the text of every power
+//	lower, upper, trim, replace OLD NEW S, contains S SUBSTR
+func (r *Renderer) TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"spell": func(id int) string {
+			name, ok := r.SpellName(id)
+			if !ok {
+				return r.unknownSpell(id)
+			}
+			return name
+		},
+		"skill": func(id int) string {
+			name, ok := r.HeroSkillName(id)
+			if !ok {
+				return r.unknownHeroSkill(id)
+			}
+			return name
+		},
+		"sign": func(value interface{}) (string, error) {
+			n, err := templateNumber(value)
+			if err != nil {
+				return "", err
+			}
+			return FormatNumber(n, true, -1), nil
+		},
+		"number": func(value interface{}, decimals int) (string, error) {
+			n, err := templateNumber(value)
+			if err != nil {
+				return "", err
+			}
+			return FormatNumber(n, false, decimals), nil
+		},
+		"texts": func(powers []ResolvedPower) []string {
+			texts := []string{}
+			for _, power := range powers {
+				texts = append(texts, power.Text)
+			}
+			return texts
+		},
+		"join":     func(list []string, sep string) string { return strings.Join(list, sep) },
+		"lower":    strings.ToLower,
+		"upper":    strings.ToUpper,
+		"trim":     strings.TrimSpace,
+		"replace":  func(old string, new string, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains": strings.Contains,
+	}
+}
+
+// ParseTemplate reads an export template file with the helpers of TemplateFuncs
+func (r *Renderer) ParseTemplate(path string) (*template.Template, error) {
+	return template.New(filepath.Base(path)).Funcs(r.TemplateFuncs()).ParseFiles(path)
+}
+
+// templateNumber accepts the number kinds a template passes, such as Data or Value of a power
+func templateNumber(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("%v is not a number", value)
+}
@@ -0,0 +1,65 @@
+package item
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/xackery/wbc3-cli/wbc3/strtab"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	r := &Renderer{Text: strtab.Table{"SPELL_NAME_12": "Fireball"}}
+	powers := []ResolvedPower{{Text: "Casts Fireball when used"}, {Text: "+3 Movement Speed"}}
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr string
+	}{
+		{"spell", `{{spell 12}}`, "Fireball", ""},
+		{"missing spell", `{{spell 13}}`, "Spell 13", ""},
+		{"skill", `{{skill 34}}`, "Mighty Blow", ""},
+		{"missing skill", `{{skill 999}}`, "Hero Skill 999", ""},
+		{"sign", `{{sign 5}} {{sign -5}} {{sign 0}} {{sign 1.5}}`, "+5 -5 +0 +1.5", ""},
+		{"number", `{{number 1.256 2}} {{number 1.5 -1}} {{number 3 0}}`, "1.26 1.5 3", ""},
+		{"sign not a number", `{{sign "a"}}`, "", "a is not a number"},
+		{"texts and join", `{{join (texts .) "; "}}`, "Casts Fireball when used; +3 Movement Speed", ""},
+		{"strings", `{{lower "AB"}} {{upper "ab"}} [{{trim " a "}}] {{replace "_" " " "a_b"}} {{contains "abc" "b"}}`, "ab AB [a] a b true", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New(tt.name).Funcs(r.TemplateFuncs()).Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			out := &strings.Builder{}
+			err = tmpl.Execute(out, powers)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("got %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateDataBySlot(t *testing.T) {
+	data := TemplateData{Items: []Resolved{{Name: "Rod", Slot: "Hand"}, {Name: "Ring", Slot: "Finger"}, {Name: "Axe", Slot: "Hand"}}}
+	tmpl := template.Must(template.New("slots").Parse(`{{range .BySlot "Hand"}}{{.Name}} {{end}}`))
+	out := &strings.Builder{}
+	err := tmpl.Execute(out, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "Rod Axe " {
+		t.Errorf("got %q, want %q", out.String(), "Rod Axe ")
+	}
+}
@@ -0,0 +1,114 @@
+package item
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Element is a child element the item structs do not model, kept as read
+type Element struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+// Unmodeled is an attribute or element of item.xml that no field of the item structs decodes
+type Unmodeled struct {
+	// Item is the index of the item in Items.Items, -1 for the Items element itself
+	Item   int
+	ItemID string
+	// Path is the element holding it, such as Power[1], empty for the item itself
+	Path string
+	// Name is the attribute or element name
+	Name      string
+	Attribute bool
+	Value     string
+}
+
+func (u Unmodeled) String() string {
+	where := "Items"
+	if u.Item >= 0 {
+		where = "item " + u.ItemID
+	}
+	if u.Path != "" {
+		where += " " + u.Path
+	}
+	if u.Attribute {
+		return fmt.Sprintf("%s: unmodeled attribute %s=%q", where, u.Name, u.Value)
+	}
+	return fmt.Sprintf("%s: unmodeled element <%s>", where, u.Name)
+}
+
+// Field returns the field of the item holding it, as passed to Document.FieldPosition, such as Power[1] or Mystery
+func (u Unmodeled) Field() string {
+	if u.Path == "" && !u.Attribute {
+		return u.Name
+	}
+	return u.Path
+}
+
+// FindUnmodeled returns every attribute and element of items that was only kept because the item structs do not model it
+func FindUnmodeled(items *Items) []Unmodeled {
+	found := []Unmodeled{}
+	found = appendUnmodeled(found, Unmodeled{Item: -1}, "", items.Attrs, items.Extra)
+	for i, it := range items.Items {
+		base := Unmodeled{Item: i, ItemID: strings.TrimSpace(it.ID)}
+		found = appendUnmodeled(found, base, "", it.Attrs, it.Extra)
+
+		// every child struct carries its own Attrs and Extra
+		v := reflect.ValueOf(it)
+		for f := 0; f < v.NumField(); f++ {
+			name, _ := xmlTag(v.Type().Field(f))
+			field := v.Field(f)
+			switch field.Kind() {
+			case reflect.Struct:
+				found = appendChild(found, base, name, field)
+			case reflect.Slice:
+				if field.Type().Elem().Kind() != reflect.Struct || name == "" {
+					continue
+				}
+				for n := 0; n < field.Len(); n++ {
+					found = appendChild(found, base, fmt.Sprintf("%s[%d]", name, n), field.Index(n))
+				}
+			}
+		}
+	}
+	return found
+}
+
+func appendChild(found []Unmodeled, base Unmodeled, path string, v reflect.Value) []Unmodeled {
+	attrs, ok := v.FieldByName("Attrs").Interface().([]xml.Attr)
+	if !ok {
+		return found
+	}
+	extra, _ := v.FieldByName("Extra").Interface().([]Element)
+	return appendUnmodeled(found, base, path, attrs, extra)
+}
+
+func appendUnmodeled(found []Unmodeled, base Unmodeled, path string, attrs []xml.Attr, extra []Element) []Unmodeled {
+	base.Path = path
+	for _, attr := range attrs {
+		u := base
+		u.Name = qualifiedName(attr.Name)
+		u.Attribute = true
+		u.Value = attr.Value
+		found = append(found, u)
+	}
+	for _, element := range extra {
+		u := base
+		u.Name = qualifiedName(element.XMLName)
+		u.Value = strings.TrimSpace(element.Inner)
+		found = append(found, u)
+	}
+	return found
+}
+
+// qualifiedName is name with its namespace prefix, if any
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
@@ -0,0 +1,272 @@
+package item
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Wiki writes items as MediaWiki markup: a sortable wikitable per slot and a page per item calling an infobox template
+type Wiki struct {
+	// Columns is the column set of the listing tables, DefaultColumns when empty
+	Columns []Column
+	// CombinePowers writes every power of an item into a single Powers cell instead of a column each
+	CombinePowers bool
+	// Infobox is the template each item page calls, "Item infobox" when empty
+	Infobox string
+	// Title is the title of the listing page, "Items" when empty
+	Title string
+}
+
+// WikiPage is a page of a MediaWiki import
+type WikiPage struct {
+	Title string
+	Text  string
+}
+
+// PageTitle returns the title of the listing page
+func (w Wiki) PageTitle() string {
+	if w.Title == "" {
+		return "Items"
+	}
+	return w.Title
+}
+
+// Listing renders items as a sortable wikitable per slot, in the order of slots. Slots without items are skipped and
+// the name cell links to the item page. Items of a slot missing from slots, such as one of an unknown pickup sound,
+// follow in a table per slot, so every item page of Pages is listed
+func (w Wiki) Listing(items []Resolved, slots []Slot) string {
+	titles := w.PageTitles(items)
+	bySlot := make(map[Slot][]int)
+	for i, item := range items {
+		bySlot[item.Slot] = append(bySlot[item.Slot], i)
+	}
+
+	out := ""
+	for _, slot := range tableSlots(items, slots) {
+		entries := bySlot[slot]
+		if len(entries) == 0 {
+			continue
+		}
+		slotItems := []Resolved{}
+		for _, i := range entries {
+			slotItems = append(slotItems, items[i])
+		}
+		powers := MostPowers(slotItems)
+
+		out += "== " + wikiCell(string(slot)) + " ==\n"
+		out += "{| class=\"wikitable sortable\"\n"
+		headers := []string{}
+		for _, header := range columnHeaders(w.columns(), w.CombinePowers, powers) {
+			headers = append(headers, wikiCell(header))
+		}
+		out += "! " + strings.Join(headers, " !! ") + "\n"
+		for _, i := range entries {
+			title := titles[i]
+			cells := columnCells(w.columns(), w.CombinePowers, items[i], powers, func(field string, value string) string {
+				if field == "name" {
+					return wikiLink(title, value)
+				}
+				return wikiCell(value)
+			})
+			out += "|-\n| " + strings.Join(cells, " || ") + "\n"
+		}
+		out += "|}\n\n"
+	}
+	return out
+}
+
+// Page renders the page of an item: its infobox, description and category
+func (w Wiki) Page(item Resolved) string {
+	infobox := w.Infobox
+	if infobox == "" {
+		infobox = "Item infobox"
+	}
+
+	params := [][2]string{
+		{"name", item.Name},
+		{"iconrow", strconv.Itoa(item.IconRow)},
+		{"iconcol", strconv.Itoa(item.IconCol)},
+		{"slot", string(item.Slot)},
+		{"rarity", string(item.Rarity)},
+		{"level", string(item.Level)},
+		{"value", strconv.Itoa(item.Value)},
+		{"durability", strconv.Itoa(item.Durability)},
+	}
+	for i, power := range item.Powers {
+		params = append(params, [2]string{fmt.Sprintf("power%d", i+1), power.Text})
+	}
+	params = append(params, [2]string{"requirements", item.RequirementsText}, [2]string{"cursed", item.CursedText})
+
+	out := "{{" + infobox + "\n"
+	for _, param := range params {
+		out += strings.TrimRight("| "+param[0]+" = "+wikiCell(param[1]), " ") + "\n"
+	}
+	out += "}}\n"
+	if item.Description != "" {
+		out += strings.TrimSpace(item.Description) + "\n"
+	}
+	out += "\n[[Category:Items]]\n"
+	return out
+}
+
+// Pages returns the listing page followed by a page per item, titled by PageTitles
+func (w Wiki) Pages(items []Resolved, slots []Slot) []WikiPage {
+	pages := []WikiPage{{Title: w.PageTitle(), Text: w.Listing(items, slots)}}
+	for i, title := range w.PageTitles(items) {
+		pages = append(pages, WikiPage{Title: title, Text: w.Page(items[i])})
+	}
+	return pages
+}
+
+func (w Wiki) columns() []Column {
+	if len(w.Columns) == 0 {
+		return DefaultColumns
+	}
+	return w.Columns
+}
+
+// PageTitles returns the wiki page title of every item: its name without characters MediaWiki forbids in titles,
+// "Item ID" when empty. Names that are the same page to MediaWiki, which ignores the case of the first letter
+// and reads _ as a space, or the same page as the listing get " (item ID)" appended. A title that still names a page
+// taken by another, such as a generated "Item 6" next to an item named Item 6, gets " (2)", " (3)", ... appended
+func (w Wiki) PageTitles(items []Resolved) []string {
+	names := []string{}
+	count := map[string]int{wikiTitleKey(w.PageTitle()): 1}
+	for _, item := range items {
+		name := wikiTitle(item.Name)
+		names = append(names, name)
+		if name != "" {
+			count[wikiTitleKey(name)]++
+		}
+	}
+
+	titles := make([]string, len(items))
+	used := map[string]bool{wikiTitleKey(w.PageTitle()): true}
+	// named items first, so generated titles give way to real names
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		if count[wikiTitleKey(name)] > 1 {
+			name = fmt.Sprintf("%s (item %d)", name, items[i].ID)
+		}
+		titles[i] = uniqueTitle(name, used)
+	}
+	for i, name := range names {
+		if name != "" {
+			continue
+		}
+		titles[i] = uniqueTitle(fmt.Sprintf("Item %d", items[i].ID), used)
+	}
+	return titles
+}
+
+// uniqueTitle appends " (2)", " (3)", ... to title until it names a page no title in used names, and marks it used
+func uniqueTitle(title string, used map[string]bool) string {
+	unique := title
+	for n := 2; used[wikiTitleKey(unique)]; n++ {
+		unique = fmt.Sprintf("%s (%d)", title, n)
+	}
+	used[wikiTitleKey(unique)] = true
+	return unique
+}
+
+// wikiTitleKey is the page a title names: _ read as a space and the first letter upper cased
+func wikiTitleKey(title string) string {
+	title = strings.Join(strings.Fields(strings.ReplaceAll(title, "_", " ")), " ")
+	first, size := utf8.DecodeRuneInString(title)
+	return string(unicode.ToUpper(first)) + title[size:]
+}
+
+// wikiTitle drops the characters MediaWiki does not allow in a page title
+func wikiTitle(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune("#<>[]|{}", r) {
+			return -1
+		}
+		return r
+	}, name)
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// wikiLink links to a page, shown as text when it differs from the title
+func wikiLink(title string, text string) string {
+	text = wikiCell(text)
+	if text == title {
+		return "[[" + title + "]]"
+	}
+	return "[[" + title + "|" + text + "]]"
+}
+
+// wikiCell escapes pipes and drops line breaks so value stays in its table cell or template parameter
+func wikiCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "&#124;")
+	value = strings.ReplaceAll(value, "\r\n", " ")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// wikiExport is the MediaWiki export format read by Special:Import and importDump.php
+type wikiExport struct {
+	XMLName xml.Name      `xml:"http://www.mediawiki.org/xml/export-0.11/ mediawiki"`
+	Version string        `xml:"version,attr"`
+	Pages   []wikiXMLPage `xml:"page"`
+}
+
+type wikiXMLPage struct {
+	Title    string          `xml:"title"`
+	NS       int             `xml:"ns"`
+	Revision wikiXMLRevision `xml:"revision"`
+}
+
+type wikiXMLRevision struct {
+	Timestamp   string `xml:"timestamp"`
+	Contributor struct {
+		Username string `xml:"username"`
+	} `xml:"contributor"`
+	Comment string      `xml:"comment"`
+	Model   string      `xml:"model"`
+	Format  string      `xml:"format"`
+	Text    wikiXMLText `xml:"text"`
+}
+
+type wikiXMLText struct {
+	Space string `xml:"http://www.w3.org/XML/1998/namespace space,attr"`
+	Text  string `xml:",innerxml"`
+}
+
+// wikiXMLEscape escapes text for wikiXMLText, keeping line breaks readable unlike xml.EscapeText
+var wikiXMLEscape = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+
+// WriteWikiXML writes pages as a MediaWiki export (schema 0.11) with a single revision each, dated timestamp
+func WriteWikiXML(w io.Writer, pages []WikiPage, timestamp time.Time) error {
+	export := wikiExport{Version: "0.11"}
+	for _, page := range pages {
+		p := wikiXMLPage{Title: page.Title}
+		p.Revision.Timestamp = timestamp.UTC().Format(time.RFC3339)
+		p.Revision.Contributor.Username = "wbc3"
+		p.Revision.Comment = "Imported by wbc3 items export"
+		p.Revision.Model = "wikitext"
+		p.Revision.Format = "text/x-wiki"
+		p.Revision.Text = wikiXMLText{Space: "preserve", Text: wikiXMLEscape.Replace(page.Text)}
+		export.Pages = append(export.Pages, p)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(export)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
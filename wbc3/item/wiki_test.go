@@ -0,0 +1,65 @@
+package item
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPageTitles(t *testing.T) {
+	items := []Resolved{
+		{ID: 1, Name: "Sword"},
+		{ID: 2, Name: "sword"},
+		{ID: 3, Name: "Big_Axe"},
+		{ID: 4, Name: "Big Axe"},
+		{ID: 5, Name: "[Ring]"},
+		{ID: 6, Name: ""},
+		{ID: 7, Name: "ring"},
+		{ID: 8, Name: "items"},
+		{ID: 9, Name: ""},
+		{ID: 10, Name: "Item 9"},
+		{ID: 11, Name: "Axe (item 12)"},
+		{ID: 12, Name: "axe"},
+		{ID: 13, Name: "Axe"},
+	}
+	// [Ring] loses its brackets and then names the same page as ring, items is the listing page,
+	// the generated Item 9 and the disambiguated Axe (item 12) give way to real names
+	want := []string{"Sword (item 1)", "sword (item 2)", "Big_Axe (item 3)", "Big Axe (item 4)", "Ring (item 5)", "Item 6", "ring (item 7)",
+		"items (item 8)", "Item 9 (2)", "Item 9", "Axe (item 12)", "axe (item 12) (2)", "Axe (item 13)"}
+	got := Wiki{}.PageTitles(items)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PageTitles = %q, want %q", got, want)
+	}
+}
+
+func TestWikiListsEveryPage(t *testing.T) {
+	items := []Resolved{
+		{ID: 1, Name: "Sword", Slot: SlotHand},
+		{ID: 2, Name: "Feather", Slot: "FeatherUNK"},
+	}
+	w := Wiki{}
+	pages := w.Pages(items, Slots)
+	listing := pages[0].Text
+	for _, page := range pages[1:] {
+		if !strings.Contains(listing, "[["+page.Title+"]]") {
+			t.Errorf("listing does not link to %s:\n%s", page.Title, listing)
+		}
+	}
+	if !strings.Contains(listing, "== FeatherUNK ==") {
+		t.Errorf("listing has no table for the unknown slot:\n%s", listing)
+	}
+}
+
+func TestWikiPagesUnique(t *testing.T) {
+	items := []Resolved{{ID: 1, Name: "Items"}, {ID: 2, Name: "Catalog"}, {ID: 3, Name: ""}, {ID: 4, Name: "Item 3"}}
+	for _, w := range []Wiki{{}, {Title: "catalog"}} {
+		seen := make(map[string]bool)
+		for _, page := range w.Pages(items, Slots) {
+			key := wikiTitleKey(page.Title)
+			if seen[key] {
+				t.Errorf("title %q: page %q is used twice", w.PageTitle(), page.Title)
+			}
+			seen[key] = true
+		}
+	}
+}
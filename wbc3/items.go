@@ -0,0 +1,490 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xackery/wbc3-cli/wbc3/diag"
+	"github.com/xackery/wbc3-cli/wbc3/item"
+	"github.com/xackery/wbc3-cli/wbc3/strtab"
+)
+
+// exportFormats is every format items export can write
+var exportFormats = []string{"md", "json", "csv", "tsv", "wiki"}
+
+// runItemsExport writes item.xml in every -format given (md, json, csv, tsv, wiki) and through every -template file
+func runItemsExport(g *globals, args []string) error {
+	fs := newFlagSet("items export", g)
+	fs.Usage = func() {
+		printUsage(fs, "wbc3 items export [flags]", nil)
+	}
+	f := addItemFlags(fs)
+	f.addTables(fs)
+	f.addDiag(fs)
+	phrasesPath := fs.String("phrases", os.Getenv("WBC3_PHRASES"), "csv (key,text) or json file replacing the English texts around names and numbers, such as the cast spell sentences, to translate them with -lang (env WBC3_PHRASES)")
+	skillKey := fs.String("skill-key", os.Getenv("WBC3_SKILL_KEY"), "string table key prefix of hero skill names, followed by the skill id. Names come from the hero skill table when empty (env WBC3_SKILL_KEY)")
+	skillFile := fs.String("skill-file", os.Getenv("WBC3_SKILL_FILE"), "string table of the language folder holding the -skill-key names, such as Skills.txt. Every table is searched when empty (env WBC3_SKILL_FILE)")
+	templates := fs.String("template", "", "comma separated text/template files ending in .tmpl, each written to the output directory without .tmpl, such as wiki.txt.tmpl to wiki.txt")
+	columnList := fs.String("columns", "", "comma separated markdown and wiki columns, each a field optionally renamed with =, such as name,slot,value=Gold,powers (fields: "+strings.Join(item.ColumnFields(), ", ")+")")
+	columnsPath := fs.String("columns-file", os.Getenv("WBC3_COLUMNS"), "csv (field,header) or json file of markdown and wiki columns, instead of -columns (env WBC3_COLUMNS)")
+	combinePowers := fs.Bool("combine-powers", false, "write every power of an item into one Powers cell of the markdown and wiki tables instead of a column each")
+	wikiInfobox := fs.String("wiki-infobox", "Item infobox", "template each wiki item page calls")
+	wikiTitle := fs.String("wiki-title", "Items", "title of the wiki page listing every item")
+	keepGoing := fs.Bool("keep-going", false, "render a placeholder for fields and powers that fail, finish the export and report every failure at the end")
+	format := fs.String("format", "md", "comma separated output formats: "+strings.Join(exportFormats, ", ")+", each written as item.<format> in the output directory")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError("items export: unexpected argument %q", fs.Arg(0))
+	}
+	if *skillFile != "" && *skillKey == "" {
+		return usageError("items export: -skill-file needs -skill-key")
+	}
+	formats := []string{}
+	if *templates == "" || flagSet(fs, "format") {
+		formats, err = parseFormats(*format, exportFormats)
+		if err != nil {
+			return err
+		}
+	}
+	templatePaths := []string{}
+	for _, path := range strings.Split(*templates, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".tmpl") {
+			return usageError("items export: template %s does not end in .tmpl", path)
+		}
+		templatePaths = append(templatePaths, path)
+	}
+	var columns []item.Column
+	switch {
+	case *columnList != "" && *columnsPath != "":
+		return usageError("items export: set -columns or -columns-file, not both")
+	case *columnList != "":
+		columns, err = item.ParseColumns(*columnList)
+		if err != nil {
+			return usageError("items export: -columns: %s", err)
+		}
+	case *columnsPath != "":
+		columns, err = item.LoadColumns(*columnsPath)
+		if err != nil {
+			return inputError(fmt.Errorf("load columns: %w", err))
+		}
+	}
+
+	dir, lookup, fallback, err := loadLookup(g)
+	if err != nil {
+		return err
+	}
+
+	var skillText strtab.Lookup
+	if *skillFile != "" {
+		skillText, err = loadTable(filepath.Join(dir, *skillFile))
+		if err != nil {
+			return inputError(fmt.Errorf("load skill names: %w", err))
+		}
+	}
+
+	skills, err := loadSkills(f.skills)
+	if err != nil {
+		return inputError(fmt.Errorf("load skills: %w", err))
+	}
+
+	slots, err := loadSlots(f.slots)
+	if err != nil {
+		return inputError(fmt.Errorf("load slots: %w", err))
+	}
+
+	powerFormats, err := loadFormats(f.powers)
+	if err != nil {
+		return inputError(fmt.Errorf("load powers: %w", err))
+	}
+
+	var phrases item.Phrases
+	if *phrasesPath != "" {
+		phrases, err = item.LoadPhrases(*phrasesPath)
+		if err != nil {
+			return inputError(fmt.Errorf("load phrases: %w", err))
+		}
+	}
+
+	r, err := os.Open(f.in)
+	if err != nil {
+		return inputError(err)
+	}
+	defer r.Close()
+
+	doc, err := item.ParseDocument(r)
+	if err != nil {
+		return dataError(fmt.Errorf("parse %s: %w", f.in, err))
+	}
+
+	// every data problem is collected so -diag can write them, also when the export fails
+	diags := &diagnostics{path: f.in, doc: doc}
+	finish := func(err error) error {
+		if f.diag == "" {
+			return err
+		}
+		diagErr := writeDiagnostics(f.diag, diags.list)
+		if err != nil {
+			return err
+		}
+		return diagErr
+	}
+
+	unmodeled := item.FindUnmodeled(doc.Items)
+	diags.addUnmodeled(unmodeled, f.strict)
+	err = reportUnmodeled(doc, f.in, unmodeled, f.strict)
+	if err != nil {
+		return finish(err)
+	}
+
+	// every data problem is found by the linter, so -diag and items lint report the same rules and messages
+	linter := &item.Linter{Text: lookup, Skills: skills, Slots: slots, Formats: powerFormats}
+	problems := linter.Lint(doc.Items)
+	diags.addProblems(problems)
+	for _, problem := range problems {
+		// unknown power types are reported once per type below
+		if ruleSeverity(problem.Check) == diag.SeverityWarning && problem.Check != item.CheckPowerType {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", problem)
+		}
+	}
+
+	records, err := item.Decode(doc.Items, slots)
+	if err != nil && !*keepGoing {
+		return finish(dataError(fmt.Errorf("decode items:\n%w", err)))
+	}
+
+	renderer := &item.Renderer{Text: lookup, SkillKey: *skillKey, SkillText: skillText, Skills: skills, Formats: powerFormats, Phrases: phrases}
+	resolved := []item.Resolved{}
+	for i, record := range records {
+		entry, err := renderer.Resolve(record)
+		if err != nil {
+			diags.addPowerErrors(i, doc.Items.Items[i].ID, err)
+			if !*keepGoing {
+				return finish(dataError(fmt.Errorf("item %d: %w", record.ID, err)))
+			}
+		}
+		resolved = append(resolved, entry)
+	}
+
+	// power types without a format are rendered as "+data Type", flag them once per type as first written
+	unrecognized := make(map[string]int)
+	unrecognizedOrder := []item.PowerType{}
+	for _, entry := range resolved {
+		for _, power := range entry.Powers {
+			if !power.Unrecognized {
+				continue
+			}
+			if unrecognized[power.Type.Key()] == 0 {
+				unrecognizedOrder = append(unrecognizedOrder, power.Type)
+			}
+			unrecognized[power.Type.Key()]++
+		}
+	}
+	for _, powerType := range unrecognizedOrder {
+		fmt.Fprintf(os.Stderr, "warning: power type %q has no format, %d powers rendered as \"+data %s\"\n", powerType, unrecognized[powerType.Key()], powerType)
+	}
+
+	for _, format := range formats {
+		buf := &bytes.Buffer{}
+		switch format {
+		case "md":
+			table := item.MarkdownTable{Columns: columns, CombinePowers: *combinePowers}
+			buf.WriteString(table.Write(resolved, slots.Slots()))
+		case "json":
+			err = item.WriteJSON(buf, resolved)
+			if err != nil {
+				return fmt.Errorf("encode json: %w", err)
+			}
+		case "csv":
+			err = item.WriteCSV(buf, resolved, ',')
+			if err != nil {
+				return fmt.Errorf("encode csv: %w", err)
+			}
+		case "tsv":
+			err = item.WriteCSV(buf, resolved, '\t')
+			if err != nil {
+				return fmt.Errorf("encode tsv: %w", err)
+			}
+		case "wiki":
+			err = writeWiki(g.outDir, item.Wiki{Columns: columns, CombinePowers: *combinePowers, Infobox: *wikiInfobox, Title: *wikiTitle}, resolved, slots.Slots())
+			if err != nil {
+				return finish(err)
+			}
+			continue
+		}
+
+		err = writeFile(filepath.Join(g.outDir, "item."+format), buf.Bytes())
+		if err != nil {
+			return finish(err)
+		}
+	}
+
+	data := item.TemplateData{Items: resolved, Slots: slots.Slots()}
+	for _, path := range templatePaths {
+		tmpl, err := renderer.ParseTemplate(path)
+		if err != nil {
+			return finish(inputError(fmt.Errorf("parse template: %w", err)))
+		}
+		buf := &bytes.Buffer{}
+		err = tmpl.Execute(buf, data)
+		if err != nil {
+			return finish(inputError(fmt.Errorf("execute template: %w", err)))
+		}
+		name := filepath.Base(path)
+		err = writeFile(filepath.Join(g.outDir, name[:len(name)-len(".tmpl")]), buf.Bytes())
+		if err != nil {
+			return finish(err)
+		}
+	}
+
+	reportFallback(dir, fallback, renderer, *skillKey)
+
+	if *keepGoing {
+		failed := diags.summary(os.Stderr)
+		if failed > 0 {
+			return finish(dataError(fmt.Errorf("%d failures, the export was written with placeholders", failed)))
+		}
+	}
+	return finish(nil)
+}
+
+// writeWiki writes the wiki listing to item.wiki, every item page to wiki/<title>.wiki
+// and both as a MediaWiki import to item.wiki.xml
+func writeWiki(outDir string, wiki item.Wiki, resolved []item.Resolved, slots []item.Slot) error {
+	pages := wiki.Pages(resolved, slots)
+	err := writeFile(filepath.Join(outDir, "item.wiki"), []byte(pages[0].Text))
+	if err != nil {
+		return err
+	}
+	used := make(map[string]bool)
+	for _, page := range pages[1:] {
+		name := uniqueFileName(wikiFileName(page.Title), used)
+		err = writeFile(filepath.Join(outDir, "wiki", name+".wiki"), []byte(page.Text))
+		if err != nil {
+			return err
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	err = item.WriteWikiXML(buf, pages, time.Now())
+	if err != nil {
+		return fmt.Errorf("encode wiki xml: %w", err)
+	}
+	return writeFile(filepath.Join(outDir, "item.wiki.xml"), buf.Bytes())
+}
+
+// wikiFileName replaces the characters of a page title that file systems do not allow
+func wikiFileName(title string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, title)
+}
+
+// uniqueFileName appends _2, _3, ... to name until it differs from every name in used ignoring case,
+// as titles such as A/B and A:B or Sword and SWORD map to the same file on some systems, and marks it used
+func uniqueFileName(name string, used map[string]bool) string {
+	unique := name
+	for n := 2; used[strings.ToLower(unique)]; n++ {
+		unique = fmt.Sprintf("%s_%d", name, n)
+	}
+	used[strings.ToLower(unique)] = true
+	return unique
+}
+
+// loadLookup finds the language folder of g and loads its string tables,
+// falling back to English for missing keys when another -lang of the game directory is used.
+// A -text folder is used as is, it has no English folder next to it to fall back to
+func loadLookup(g *globals) (string, strtab.Lookup, *strtab.Fallback, error) {
+	dir, err := findTextDir(g.textDir, g.gameDir, g.lang)
+	if err != nil {
+		return "", nil, nil, inputError(fmt.Errorf("find text: %w", err))
+	}
+
+	text, err := loadText(dir)
+	if err != nil {
+		return "", nil, nil, inputError(fmt.Errorf("load text: %w", err))
+	}
+
+	var lookup strtab.Lookup = text
+	var fallback *strtab.Fallback
+	if g.textDir == "" && !strings.EqualFold(g.lang, defaultLang) {
+		fallbackDir := filepath.Join(filepath.Dir(dir), defaultLang)
+		fallbackText, err := loadText(fallbackDir)
+		if err != nil {
+			return "", nil, nil, inputError(fmt.Errorf("load %s text: %w", defaultLang, err))
+		}
+		fallback = strtab.NewFallback(text, fallbackText)
+		lookup = fallback
+	}
+
+	return dir, lookup, fallback, nil
+}
+
+// reportFallback prints how many keys of a language other than English fell back to English,
+// and how many hero skill names are English because they came from the skill table
+func reportFallback(dir string, fallback *strtab.Fallback, renderer *item.Renderer, skillKey string) {
+	lang := filepath.Base(dir)
+	if fallback != nil {
+		fmt.Fprintf(os.Stderr, "%s: %d keys fell back to %s\n", lang, len(fallback.FellBack()), defaultLang)
+	}
+	skills := len(renderer.SkillTableNames())
+	if skills == 0 || strings.EqualFold(lang, defaultLang) {
+		return
+	}
+	if skillKey == "" {
+		fmt.Fprintf(os.Stderr, "%s: %d hero skill names are %s from the skill table, set -skill-key to read them from the string tables\n", lang, skills, defaultLang)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d hero skill names are %s from the skill table, the string tables have no %s<id> key for them\n", lang, skills, defaultLang, skillKey)
+}
+
+// loadSlots returns the built in pickup sound to slot map with the entries of path merged over it
+func loadSlots(path string) (item.SlotMap, error) {
+	slots := item.DefaultSlotMap()
+	if path == "" {
+		return slots, nil
+	}
+	extra, err := item.LoadSlotMap(path)
+	if err != nil {
+		return nil, err
+	}
+	slots.Merge(extra)
+	return slots, nil
+}
+
+// loadFormats returns the built in power formats with the texts of path merged over them
+func loadFormats(path string) (*item.PowerFormats, error) {
+	formats := item.DefaultPowerFormats()
+	if path == "" {
+		return formats, nil
+	}
+	texts, err := item.LoadPowerTexts(path)
+	if err != nil {
+		return nil, err
+	}
+	err = formats.Merge(texts)
+	if err != nil {
+		return nil, err
+	}
+	return formats, nil
+}
+
+// reportUnmodeled warns about the attributes and elements of item.xml the item structs do not model,
+// or when strict fails listing every one of them
+func reportUnmodeled(doc *item.Document, path string, found []item.Unmodeled, strict bool) error {
+	if len(found) == 0 {
+		return nil
+	}
+	if !strict {
+		fmt.Fprintf(os.Stderr, "warning: %s has %d unmodeled attributes or elements, run with -strict to list them\n", path, len(found))
+		return nil
+	}
+
+	lines := []string{}
+	for _, u := range found {
+		pos, ok := doc.FieldPosition(u.Item, u.Field())
+		if !ok {
+			lines = append(lines, fmt.Sprintf("%s: %s", path, u))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s:%s: %s", path, pos, u))
+	}
+	return dataError(fmt.Errorf("%s has %d unmodeled attributes or elements:\n%s", path, len(found), strings.Join(lines, "\n")))
+}
+
+// parseFormats splits a comma separated list of formats, rejecting any not in known
+func parseFormats(list string, known []string) ([]string, error) {
+	formats := []string{}
+	for _, format := range strings.Split(list, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "" {
+			continue
+		}
+		ok := false
+		for _, k := range known {
+			if k == format {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return nil, usageError("unknown format %q, use %s", format, strings.Join(known, ", "))
+		}
+		formats = append(formats, format)
+	}
+	if len(formats) == 0 {
+		return nil, usageError("no format given, use %s", strings.Join(known, ", "))
+	}
+	return formats, nil
+}
+
+// writeFile writes data to path, creating its directory
+func writeFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return outputError(fmt.Errorf("create output dir: %w", err))
+	}
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return outputError(fmt.Errorf("write %s: %w", path, err))
+	}
+	return nil
+}
+
+// loadTable reads a single string table, malformed lines are reported as warnings
+func loadTable(path string) (strtab.Table, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	table, err := strtab.Parse(r)
+	var lineErrs strtab.LineErrors
+	if errors.As(err, &lineErrs) {
+		for _, lineErr := range lineErrs {
+			fmt.Fprintf(os.Stderr, "warning: %s: %s\n", filepath.Base(path), lineErr)
+		}
+		err = nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return table, nil
+}
+
+// loadText reads every string table in dir, malformed lines are reported as warnings.
+// A table in another encoding fails, as its names would be garbled
+func loadText(dir string) (*strtab.Catalog, error) {
+	catalog, err := strtab.LoadDir(dir)
+	var fileErrs strtab.FileErrors
+	if errors.As(err, &fileErrs) {
+		for _, fileErr := range fileErrs {
+			if errors.Is(fileErr, strtab.ErrNotUTF8) {
+				return nil, fmt.Errorf("%s: %w", dir, fileErr)
+			}
+		}
+		for _, fileErr := range fileErrs {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", fileErr)
+		}
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
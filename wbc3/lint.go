@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/xackery/wbc3-cli/wbc3/diag"
+	"github.com/xackery/wbc3-cli/wbc3/item"
+)
+
+// lintFormats is every format items lint can print
+var lintFormats = []string{"text", "json", "sarif"}
+
+// runItemsLint checks item.xml for mistakes and prints every one with its line and column
+func runItemsLint(g *globals, args []string) error {
+	fs := newFlagSet("items lint", g)
+	fs.Usage = func() {
+		printUsage(fs, "wbc3 items lint [flags]", nil)
+	}
+	f := addItemFlags(fs)
+	f.addTables(fs)
+	format := fs.String("format", "text", "output format: text, json or sarif")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError("items lint: unexpected argument %q", fs.Arg(0))
+	}
+	outFormats, err := parseFormats(*format, lintFormats)
+	if err != nil {
+		return err
+	}
+	if len(outFormats) > 1 {
+		return usageError("items lint: only one format can be written to stdout")
+	}
+
+	// the string tables only serve the unknown-spell check, so lint also runs without a game install, such as in a mod's ci
+	_, lookup, _, err := loadLookup(g)
+	if err != nil {
+		if g.textDir != "" || g.gameDir != "" {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "warning: no string tables found, %s is not checked (set -game or -text to check it)\n", item.CheckSpell)
+		lookup = nil
+	}
+
+	skills, err := loadSkills(f.skills)
+	if err != nil {
+		return inputError(fmt.Errorf("load skills: %w", err))
+	}
+
+	slots, err := loadSlots(f.slots)
+	if err != nil {
+		return inputError(fmt.Errorf("load slots: %w", err))
+	}
+
+	formats, err := loadFormats(f.powers)
+	if err != nil {
+		return inputError(fmt.Errorf("load powers: %w", err))
+	}
+
+	r, err := os.Open(f.in)
+	if err != nil {
+		return inputError(err)
+	}
+	defer r.Close()
+
+	doc, err := item.ParseDocument(r)
+	if err != nil {
+		return dataError(fmt.Errorf("parse %s: %w", f.in, err))
+	}
+
+	diags := &diagnostics{path: f.in, doc: doc}
+	diags.addUnmodeled(item.FindUnmodeled(doc.Items), f.strict)
+	linter := &item.Linter{Text: lookup, Skills: skills, Slots: slots, Formats: formats}
+	diags.addProblems(linter.Lint(doc.Items))
+	// in file order
+	sort.SliceStable(diags.list, func(a, b int) bool {
+		if diags.list[a].Line != diags.list[b].Line {
+			return diags.list[a].Line < diags.list[b].Line
+		}
+		return diags.list[a].Column < diags.list[b].Column
+	})
+
+	if outFormats[0] == "text" {
+		for _, entry := range diags.list {
+			fmt.Println(entry)
+		}
+		if len(diags.list) == 0 {
+			fmt.Printf("%s: no problems\n", f.in)
+		}
+	} else {
+		data, err := encodeDiagnostics(outFormats[0], diags.list)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		if err != nil {
+			return outputError(err)
+		}
+	}
+
+	// only errors fail the lint, warnings such as unknown-power-type and unmodeled notes are reported and pass
+	errorCount := 0
+	for _, entry := range diags.list {
+		if entry.Severity == diag.SeverityError {
+			errorCount++
+		}
+	}
+	if errorCount > 0 {
+		return dataError(fmt.Errorf("%s: %d problems, %d of them errors", f.in, len(diags.list), errorCount))
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// exit codes, one per failure class
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+	exitInput   = 3
+	exitData    = 4
+	exitOutput  = 5
+)
+
+// defaultLang is the language folder missing keys fall back to
+const defaultLang = "English"
+
+// globals are the flags every command accepts, before or after the command name
+type globals struct {
+	gameDir string
+	lang    string
+	textDir string
+	outDir  string
+}
+
+// command is a node of the command tree, either running itself or dispatching to subcommands
+type command struct {
+	name        string
+	summary     string
+	run         func(g *globals, args []string) error
+	subcommands []*command
+}
+
+// exitError carries the exit code of a failure class
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func usageError(format string, a ...interface{}) error {
+	return &exitError{code: exitUsage, err: fmt.Errorf(format, a...)}
+}
+
+func inputError(err error) error {
+	return &exitError{code: exitInput, err: err}
+}
+
+func dataError(err error) error {
+	return &exitError{code: exitData, err: err}
+}
+
+func outputError(err error) error {
+	return &exitError{code: exitOutput, err: err}
+}
+
+var commands = []*command{
+	{
+		name:    "items",
+		summary: "work with item.xml",
+		subcommands: []*command{
+			{name: "export", summary: "export item.xml as markdown, json, csv, tsv, MediaWiki or text/template output", run: runItemsExport},
+			{name: "import", summary: "apply a csv or tsv in the export layout back onto item.xml", run: runItemsImport},
+			{name: "lint", summary: "check item.xml for mistakes", run: runItemsLint},
+		},
+	},
+	{name: "skills", summary: "list, filter and look up hero skills", run: runSkills},
+	{
+		name:    "icons",
+		summary: "work with icon images",
+		subcommands: []*command{
+			{name: "convert", summary: "convert a folder of bmp icons to png", run: runIconsConvert},
+		},
+	},
+}
+
+func main() {
+	err := run(os.Args[1:])
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		os.Exit(exitOK)
+	}
+
+	fmt.Fprintln(os.Stderr, "Failed to run:", err)
+	code := exitFailure
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		code = exitErr.code
+	}
+	os.Exit(code)
+}
+
+func run(args []string) error {
+	g := newGlobals()
+	fs := newFlagSet("wbc3", g)
+	fs.Usage = func() {
+		printUsage(fs, "wbc3 [global flags] <command> [flags]", commands)
+	}
+	err := fs.Parse(args)
+	if err != nil {
+		return flagError(err)
+	}
+
+	return dispatch(g, "wbc3", commands, fs.Args())
+}
+
+// dispatch finds the command named by args[0] and runs it, or its subcommand
+func dispatch(g *globals, path string, cmds []*command, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(newFlagSet(path, g), path+" <command> [flags]", cmds)
+		if len(args) == 0 {
+			return usageError("%s: missing command", path)
+		}
+		return nil
+	}
+
+	for _, cmd := range cmds {
+		if cmd.name != args[0] {
+			continue
+		}
+		if cmd.run != nil {
+			return cmd.run(g, args[1:])
+		}
+		return dispatch(g, path+" "+cmd.name, cmd.subcommands, args[1:])
+	}
+	return usageError("%s: unknown command %q, run %s help", path, args[0], path)
+}
+
+// newGlobals returns the global flag defaults, taken from the environment
+func newGlobals() *globals {
+	return &globals{
+		gameDir: os.Getenv("WBC3_GAME_DIR"),
+		lang:    envOr("WBC3_LANG", defaultLang),
+		textDir: os.Getenv("WBC3_TEXT_DIR"),
+		outDir:  envOr("WBC3_OUT_DIR", "."),
+	}
+}
+
+// newFlagSet returns a flag set holding the global flags, so they can be given after the command too
+func newFlagSet(name string, g *globals) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&g.gameDir, "game", g.gameDir, "game install directory, searched in common steam libraries when empty (env WBC3_GAME_DIR)")
+	fs.StringVar(&g.lang, "lang", g.lang, "language folder inside the game directory, such as German or French. Missing keys fall back to English (env WBC3_LANG)")
+	fs.StringVar(&g.textDir, "text", g.textDir, "folder holding Spells.txt and the other string tables, overrides -game and -lang (env WBC3_TEXT_DIR)")
+	fs.StringVar(&g.outDir, "out", g.outDir, "output directory (env WBC3_OUT_DIR)")
+	return fs
+}
+
+// itemFlags are the flags of the items commands reading item.xml, defined here so every command names and explains them alike
+type itemFlags struct {
+	in     string
+	skills string
+	slots  string
+	powers string
+	strict bool
+	diag   string
+}
+
+// addItemFlags defines -in and -strict on fs
+func addItemFlags(fs *flag.FlagSet) *itemFlags {
+	f := &itemFlags{}
+	fs.StringVar(&f.in, "in", envOr("WBC3_ITEM_XML", "item.xml"), "input item xml (env WBC3_ITEM_XML)")
+	fs.BoolVar(&f.strict, "strict", false, "report every attribute or element item.xml has that the tool does not model as an error, instead of only counting them")
+	return f
+}
+
+// addTables defines -skills, -slots and -powers, the files merged over the built in tables
+func (f *itemFlags) addTables(fs *flag.FlagSet) {
+	addSkillsFlag(fs, &f.skills)
+	fs.StringVar(&f.slots, "slots", os.Getenv("WBC3_SLOTS"), "csv or json file of pickup sound to slot entries, merged over the built in map (env WBC3_SLOTS)")
+	fs.StringVar(&f.powers, "powers", os.Getenv("WBC3_POWERS"), "csv (type,text) or json file of power type formats, merged over the built in ones (env WBC3_POWERS)")
+}
+
+// addDiag defines -diag
+func (f *itemFlags) addDiag(fs *flag.FlagSet) {
+	fs.StringVar(&f.diag, "diag", "", "write every data problem to this file, as SARIF when it ends in .sarif and json otherwise")
+}
+
+// addSkillsFlag defines -skills, the hero skill file merged over the built in table
+func addSkillsFlag(fs *flag.FlagSet, path *string) {
+	fs.StringVar(path, "skills", os.Getenv("WBC3_SKILLS"), "csv or json file of extra or renamed hero skills, merged over the built in table (env WBC3_SKILLS)")
+}
+
+// parseFlags parses the flags of a command, turning flag failures into usage errors
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	return flagError(fs.Parse(args))
+}
+
+func flagError(err error) error {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return &exitError{code: exitUsage, err: err}
+}
+
+// flagSet reports if the flag name was given on the command line
+func flagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+func printUsage(fs *flag.FlagSet, usage string, cmds []*command) {
+	w := fs.Output()
+	fmt.Fprintf(w, "usage: %s\n", usage)
+	if len(cmds) > 0 {
+		fmt.Fprintln(w, "\ncommands:")
+		names := []string{}
+		summaries := make(map[string]string)
+		for _, cmd := range cmds {
+			names = append(names, cmd.name)
+			summary := cmd.summary
+			if len(cmd.subcommands) > 0 {
+				subs := []string{}
+				for _, sub := range cmd.subcommands {
+					subs = append(subs, sub.name)
+				}
+				summary += " (" + strings.Join(subs, ", ") + ")"
+			}
+			summaries[cmd.name] = summary
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(w, "  %-8s %s\n", name, summaries[name])
+		}
+	}
+	fmt.Fprintln(w, "\nflags:")
+	fs.PrintDefaults()
+	fmt.Fprintf(w, "\nexit codes: %d ok, %d failure, %d usage, %d missing or unreadable input, %d invalid data, %d output not written\n", exitOK, exitFailure, exitUsage, exitInput, exitData, exitOutput)
+}
@@ -0,0 +1,232 @@
+// Package skill holds the hero skill table, loaded from an embedded default that mods can override
+package skill
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed skills.csv
+var defaultSkills string
+
+// Category groups related hero skills
+type Category string
+
+const (
+	CategoryStat        Category = "Stat"
+	CategoryMagic       Category = "Magic"
+	CategoryProtective  Category = "Protective"
+	CategoryDamage      Category = "Damage"
+	CategoryMiscCombat  Category = "Misc Combat"
+	CategoryResource    Category = "Resource"
+	CategoryHealing     Category = "Healing"
+	CategoryElvenRunes  Category = "Elven Runes"
+	CategoryBuildings   Category = "Buildings"
+	CategoryTroopMorale Category = "Troop Morale"
+	CategoryTroopXP     Category = "Troop XP"
+	CategoryProfession  Category = "Profession"
+	CategoryMisc        Category = "Misc"
+)
+
+// Stat is the hero attribute a stat skill is tied to
+type Stat string
+
+const (
+	StatStrength     Stat = "Strength"
+	StatDexterity    Stat = "Dexterity"
+	StatIntelligence Stat = "Intelligence"
+	StatCharisma     Stat = "Charisma"
+)
+
+// Skill is a single hero skill
+type Skill struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name"`
+	Category Category `json:"category"`
+	Stat     Stat     `json:"stat,omitempty"`
+}
+
+// Table is a set of hero skills keyed by id
+type Table struct {
+	byID map[int]Skill
+}
+
+// Default returns the hero skills shipped with the game
+func Default() *Table {
+	t, err := ParseCSV(strings.NewReader(defaultSkills))
+	if err != nil {
+		panic(fmt.Sprintf("embedded skills.csv: %s", err))
+	}
+	return t
+}
+
+// Load reads a skill table from a .csv or .json file
+func Load(path string) (*Table, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseCSV(r)
+	case ".json":
+		return ParseJSON(r)
+	}
+	return nil, fmt.Errorf("%s: unsupported skill file, use .csv or .json", path)
+}
+
+// ParseCSV reads a skill table with an id,name,category,stat header, category and stat are optional
+func ParseCSV(r io.Reader) (*Table, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("read csv: empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"id", "name"} {
+		_, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("read csv: missing %s column", name)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	skills := []Skill{}
+	for i, record := range records[1:] {
+		id, err := strconv.Atoi(field(record, "id"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d id: %w", i+2, err)
+		}
+		skills = append(skills, Skill{
+			ID:       id,
+			Name:     field(record, "name"),
+			Category: Category(field(record, "category")),
+			Stat:     Stat(field(record, "stat")),
+		})
+	}
+	return New(skills)
+}
+
+// ParseJSON reads a skill table from an array of {"id", "name", "category", "stat"} objects
+func ParseJSON(r io.Reader) (*Table, error) {
+	skills := []Skill{}
+	err := json.NewDecoder(r).Decode(&skills)
+	if err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+	return New(skills)
+}
+
+// New returns a table of skills, rejecting duplicate ids and missing names
+func New(skills []Skill) (*Table, error) {
+	t := &Table{byID: make(map[int]Skill)}
+	for _, s := range skills {
+		if s.Name == "" {
+			return nil, fmt.Errorf("skill %d: missing name", s.ID)
+		}
+		_, ok := t.byID[s.ID]
+		if ok {
+			return nil, fmt.Errorf("skill %d: duplicate id", s.ID)
+		}
+		t.byID[s.ID] = s
+	}
+	return t, nil
+}
+
+// Merge overrides skills of t with every skill in other, adding ones t does not have
+func (t *Table) Merge(other *Table) {
+	for id, s := range other.byID {
+		t.byID[id] = s
+	}
+}
+
+// Get returns a skill by id
+func (t *Table) Get(id int) (Skill, bool) {
+	s, ok := t.byID[id]
+	return s, ok
+}
+
+// Name returns the display name of a skill by id
+func (t *Table) Name(id int) (string, bool) {
+	s, ok := t.byID[id]
+	return s.Name, ok
+}
+
+// All returns every skill, sorted by id
+func (t *Table) All() []Skill {
+	skills := make([]Skill, 0, len(t.byID))
+	for _, s := range t.byID {
+		skills = append(skills, s)
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].ID < skills[j].ID })
+	return skills
+}
+
+// Find returns a skill by display name, ignoring case
+func (t *Table) Find(name string) (Skill, bool) {
+	for _, s := range t.All() {
+		if strings.EqualFold(s.Name, name) {
+			return s, true
+		}
+	}
+	return Skill{}, false
+}
+
+// Lookup returns a skill by id when query is a number, otherwise by display name
+func (t *Table) Lookup(query string) (Skill, bool) {
+	id, err := strconv.Atoi(strings.TrimSpace(query))
+	if err == nil {
+		return t.Get(id)
+	}
+	return t.Find(strings.TrimSpace(query))
+}
+
+// ByCategory returns every skill of category, ignoring case, sorted by id
+func (t *Table) ByCategory(category Category) []Skill {
+	skills := []Skill{}
+	for _, s := range t.All() {
+		if strings.EqualFold(string(s.Category), string(category)) {
+			skills = append(skills, s)
+		}
+	}
+	return skills
+}
+
+// Categories returns every category in use, in order of the first skill using it
+func (t *Table) Categories() []Category {
+	seen := make(map[Category]bool)
+	categories := []Category{}
+	for _, s := range t.All() {
+		if seen[s.Category] {
+			continue
+		}
+		seen[s.Category] = true
+		categories = append(categories, s.Category)
+	}
+	return categories
+}
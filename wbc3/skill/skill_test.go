@@ -0,0 +1,72 @@
+package skill
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []Skill
+		wantErr string
+	}{
+		{"every column", "id,name,category,stat\n# mod skills\n200,Rune Lore,Elven Runes,\n201, Brawn ,Stat,Strength\n", []Skill{{ID: 200, Name: "Rune Lore", Category: CategoryElvenRunes}, {ID: 201, Name: "Brawn", Category: CategoryStat, Stat: StatStrength}}, ""},
+		{"columns in any order without optional ones", "Name,ID\nRune Lore,200\n", []Skill{{ID: 200, Name: "Rune Lore"}}, ""},
+		{"empty", "", nil, "read csv: empty"},
+		{"no name column", "id,category\n200,Magic\n", nil, "missing name column"},
+		{"bad id", "id,name\nabc,Rune Lore\n", nil, "row 2 id"},
+		{"missing name", "id,name\n200,\n", nil, "skill 200: missing name"},
+		{"duplicate id", "id,name\n200,Rune Lore\n200,Brawn\n", nil, "skill 200: duplicate id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := ParseCSV(strings.NewReader(tt.in))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(table.All(), tt.want) {
+				t.Errorf("All = %+v, want %+v", table.All(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	table, err := ParseJSON(strings.NewReader(`[{"id": 200, "name": "Rune Lore", "category": "Elven Runes"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Skill{{ID: 200, Name: "Rune Lore", Category: CategoryElvenRunes}}
+	if !reflect.DeepEqual(table.All(), want) {
+		t.Errorf("All = %+v, want %+v", table.All(), want)
+	}
+
+	_, err = ParseJSON(strings.NewReader(`[{"id": 200}]`))
+	if err == nil {
+		t.Error("ParseJSON without a name succeeded")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	table := Default()
+	extra, err := ParseCSV(strings.NewReader("id,name\n34,Crushing Blow\n200,Rune Lore\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	table.Merge(extra)
+	for id, want := range map[int]string{1: "Ferocity", 34: "Crushing Blow", 200: "Rune Lore"} {
+		name, ok := table.Name(id)
+		if !ok || name != want {
+			t.Errorf("Name(%d) = %q, %v, want %q", id, name, ok, want)
+		}
+	}
+}
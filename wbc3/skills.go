@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/xackery/wbc3-cli/wbc3/skill"
+)
+
+// runSkills lists hero skills, optionally filtered by category or looked up by id or name
+func runSkills(g *globals, args []string) error {
+	fs := newFlagSet("skills", g)
+	fs.Usage = func() {
+		printUsage(fs, "wbc3 skills [flags] [id or name...]", nil)
+	}
+	category := fs.String("category", "", "only list skills of this category, such as Magic or \"Troop Morale\"")
+	categories := fs.Bool("categories", false, "list categories instead of skills")
+	var skillsPath string
+	addSkillsFlag(fs, &skillsPath)
+	asJSON := fs.Bool("json", false, "write json instead of a table")
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	skills, err := loadSkills(skillsPath)
+	if err != nil {
+		return inputError(fmt.Errorf("load skills: %w", err))
+	}
+
+	if *categories {
+		for _, c := range skills.Categories() {
+			fmt.Printf("%s\t%d\n", c, len(skills.ByCategory(c)))
+		}
+		return nil
+	}
+
+	list := skills.All()
+	if fs.NArg() > 0 {
+		list = []skill.Skill{}
+		for _, query := range fs.Args() {
+			s, ok := skills.Lookup(query)
+			if !ok {
+				return fmt.Errorf("skill %q not found", query)
+			}
+			list = append(list, s)
+		}
+	}
+
+	if *category != "" {
+		filtered := []skill.Skill{}
+		for _, s := range list {
+			if strings.EqualFold(string(s.Category), *category) {
+				filtered = append(filtered, s)
+			}
+		}
+		if len(filtered) == 0 {
+			return fmt.Errorf("no skills in category %q", *category)
+		}
+		list = filtered
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tName\tCategory\tStat")
+	for _, s := range list {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.ID, s.Name, s.Category, s.Stat)
+	}
+	return w.Flush()
+}
+
+// loadSkills returns the built in skill table with path merged over it when set
+func loadSkills(path string) (*skill.Table, error) {
+	skills := skill.Default()
+	if path == "" {
+		return skills, nil
+	}
+	extra, err := skill.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	skills.Merge(extra)
+	return skills, nil
+}
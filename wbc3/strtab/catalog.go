@@ -0,0 +1,126 @@
+package strtab
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Lookup resolves a string table key to its text
+type Lookup interface {
+	Lookup(key string) (string, bool)
+}
+
+// Catalog is every string table of a language folder merged into one lookup
+type Catalog struct {
+	tables map[string]Table
+	files  []string
+}
+
+// FileError is a string table that failed to load or parse
+type FileError struct {
+	File string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s: %s", e.File, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// FileErrors is every string table that failed to load or parse
+type FileErrors []*FileError
+
+func (e FileErrors) Error() string {
+	lines := []string{}
+	for _, fileErr := range e {
+		lines = append(lines, fileErr.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LoadDir parses every *.txt file in dir, such as the game's English folder.
+// The catalog holds every table that could be read even on error, and the error is a FileErrors
+func LoadDir(dir string) (*Catalog, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("glob %s: %w", dir, err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no string tables found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	c := &Catalog{tables: make(map[string]Table)}
+	errs := FileErrors{}
+	for _, path := range paths {
+		name := filepath.Base(path)
+		table, err := loadFile(path)
+		if err != nil {
+			errs = append(errs, &FileError{File: name, Err: err})
+		}
+		if table == nil {
+			continue
+		}
+		c.Add(name, table)
+	}
+
+	if len(errs) > 0 {
+		return c, errs
+	}
+	return c, nil
+}
+
+func loadFile(path string) (Table, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return Parse(r)
+}
+
+// Add adds a table to the catalog under name, such as Spells.txt.
+// Keys already provided by an earlier table win
+func (c *Catalog) Add(name string, table Table) {
+	if c.tables == nil {
+		c.tables = make(map[string]Table)
+	}
+	_, ok := c.tables[name]
+	if !ok {
+		c.files = append(c.files, name)
+	}
+	c.tables[name] = table
+}
+
+// Lookup returns the value of key from the first table that has it
+func (c *Catalog) Lookup(key string) (string, bool) {
+	for _, name := range c.files {
+		value, ok := c.tables[name][key]
+		if ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// Table returns a single table by file name, such as Spells.txt
+func (c *Catalog) Table(name string) (Table, bool) {
+	for _, file := range c.files {
+		if strings.EqualFold(file, name) {
+			return c.tables[file], true
+		}
+	}
+	return nil, false
+}
+
+// Files returns the file name of every loaded table, in load order
+func (c *Catalog) Files() []string {
+	return append([]string{}, c.files...)
+}
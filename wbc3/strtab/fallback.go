@@ -0,0 +1,39 @@
+package strtab
+
+import "sort"
+
+// Fallback looks keys up in Primary first and Secondary when missing, such as German falling back to English.
+// Every key served by Secondary is remembered so it can be reported
+type Fallback struct {
+	Primary   Lookup
+	Secondary Lookup
+	fellBack  map[string]bool
+}
+
+// NewFallback returns a lookup of primary that falls back to secondary per key
+func NewFallback(primary Lookup, secondary Lookup) *Fallback {
+	return &Fallback{Primary: primary, Secondary: secondary, fellBack: make(map[string]bool)}
+}
+
+// Lookup returns the value of key from Primary, or from Secondary when Primary lacks it
+func (f *Fallback) Lookup(key string) (string, bool) {
+	value, ok := f.Primary.Lookup(key)
+	if ok {
+		return value, true
+	}
+	value, ok = f.Secondary.Lookup(key)
+	if ok {
+		f.fellBack[key] = true
+	}
+	return value, ok
+}
+
+// FellBack returns every key that was served by Secondary, sorted
+func (f *Fallback) FellBack() []string {
+	keys := make([]string, 0, len(f.fellBack))
+	for key := range f.fellBack {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
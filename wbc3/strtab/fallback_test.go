@@ -0,0 +1,42 @@
+package strtab
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFallback(t *testing.T) {
+	german := Table{"SPELL_NAME_1": "Feuerball", "SPELL_NAME_2": ""}
+	english := Table{"SPELL_NAME_1": "Fireball", "SPELL_NAME_2": "Heal", "SPELL_NAME_3": "Bless", "SPELL_NAME_4": "Haste"}
+	tests := []struct {
+		name     string
+		keys     []string
+		want     []string
+		fellBack []string
+	}{
+		{"primary", []string{"SPELL_NAME_1"}, []string{"Feuerball"}, []string{}},
+		{"empty primary value is kept", []string{"SPELL_NAME_2"}, []string{""}, []string{}},
+		{"secondary", []string{"SPELL_NAME_4", "SPELL_NAME_3"}, []string{"Haste", "Bless"}, []string{"SPELL_NAME_3", "SPELL_NAME_4"}},
+		{"counted once", []string{"SPELL_NAME_3", "SPELL_NAME_3", "SPELL_NAME_1"}, []string{"Bless", "Bless", "Feuerball"}, []string{"SPELL_NAME_3"}},
+		{"missing everywhere", []string{"SPELL_NAME_5"}, []string{""}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFallback(german, english)
+			got := []string{}
+			for _, key := range tt.keys {
+				value, ok := f.Lookup(key)
+				if ok != (key != "SPELL_NAME_5") {
+					t.Errorf("Lookup(%q) ok = %v", key, ok)
+				}
+				got = append(got, value)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("values = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(f.FellBack(), tt.fellBack) {
+				t.Errorf("FellBack = %q, want %q", f.FellBack(), tt.fellBack)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Package strtab reads the bracket keyed string tables the game ships in its language folders, such as English/Spells.txt
+package strtab
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrNotUTF8 is returned for a string table that is neither UTF-8 nor UTF-16 with a byte order mark,
+// such as one saved as Windows-1252, as reading it anyway would garble every accented letter
+var ErrNotUTF8 = errors.New("not UTF-8, or UTF-16 with a byte order mark")
+
+// Table is every key and value of a string table
+type Table map[string]string
+
+// LineError is a line of a string table that could not be parsed
+type LineError struct {
+	Line int
+	Text string
+	Err  string
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s: %q", e.Line, e.Err, e.Text)
+}
+
+// LineErrors is every line that failed to parse
+type LineErrors []*LineError
+
+func (e LineErrors) Error() string {
+	lines := []string{}
+	for _, lineErr := range e {
+		lines = append(lines, lineErr.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Parse reads a string table made of "[KEY] value" lines, in UTF-8 or in UTF-16 with a byte order mark.
+// Blank lines and lines starting with // or ; are skipped.
+// Every well formed line is returned even on a LineErrors error, other encodings fail with ErrNotUTF8
+func Parse(r io.Reader) (Table, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+	text, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	table := make(Table)
+	errs := LineErrors{}
+	firstLine := make(map[string]int)
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		key, value, err := parseLine(line)
+		if err != "" {
+			errs = append(errs, &LineError{Line: lineNumber, Text: line, Err: err})
+			continue
+		}
+		first, ok := firstLine[key]
+		if ok {
+			errs = append(errs, &LineError{Line: lineNumber, Text: line, Err: fmt.Sprintf("duplicate key %s, first seen on line %d", key, first)})
+			continue
+		}
+		firstLine[key] = lineNumber
+		table[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return table, fmt.Errorf("read line %d: %w", lineNumber+1, err)
+	}
+
+	if len(errs) > 0 {
+		return table, errs
+	}
+	return table, nil
+}
+
+// decode returns data as text, converting UTF-16 with a byte order mark. Anything else must be UTF-8
+func decode(data []byte) (string, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16(data[2:], binary.LittleEndian)
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16(data[2:], binary.BigEndian)
+	}
+
+	for offset := 0; offset < len(data); {
+		r, size := utf8.DecodeRune(data[offset:])
+		if r == utf8.RuneError && size == 1 {
+			line := bytes.Count(data[:offset], []byte("\n")) + 1
+			return "", fmt.Errorf("line %d: byte 0x%02X: %w, save the file as UTF-8", line, data[offset], ErrNotUTF8)
+		}
+		offset += size
+	}
+	return string(data), nil
+}
+
+// decodeUTF16 converts UTF-16 without its byte order mark to text
+func decodeUTF16(data []byte, order binary.ByteOrder) (string, error) {
+	if len(data)%2 != 0 {
+		return "", fmt.Errorf("UTF-16 with an odd number of bytes, the file is cut short")
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[i*2:])
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// parseLine splits "[KEY] value" into key and value, err describes why a line is malformed
+func parseLine(line string) (key string, value string, err string) {
+	if !strings.HasPrefix(line, "[") {
+		return "", "", "missing [ before key"
+	}
+	end := strings.Index(line, "]")
+	if end < 0 {
+		return "", "", "missing ] after key"
+	}
+	key = strings.TrimSpace(line[1:end])
+	if key == "" {
+		return "", "", "empty key"
+	}
+	if strings.ContainsAny(key, " \t[") {
+		return "", "", "key contains whitespace or ["
+	}
+	return key, strings.TrimSpace(line[end+1:]), ""
+}
+
+// Get returns the value of key
+func (t Table) Get(key string) (string, bool) {
+	value, ok := t[key]
+	return value, ok
+}
+
+// Keys returns every key, sorted
+func (t Table) Keys() []string {
+	keys := make([]string, 0, len(t))
+	for key := range t {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Prefixed returns every key starting with prefix, with the prefix removed, such as
+// Prefixed("SPELL_NAME_") turning [SPELL_NAME_12] into "12"
+func (t Table) Prefixed(prefix string) map[string]string {
+	out := make(map[string]string)
+	for key, value := range t {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		out[strings.TrimPrefix(key, prefix)] = value
+	}
+	return out
+}
+
+// Lookup returns the value of key, so a Table can be used as a Lookup
+func (t Table) Lookup(key string) (string, bool) {
+	return t.Get(key)
+}
@@ -0,0 +1,140 @@
+package strtab
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Table
+		errLine []int
+	}{
+		{
+			name: "spell names",
+			in:   "[SPELL_NAME_1] Fireball\n[SPELL_NAME_12] Bless\n[SPELL_NAME_123] Meteor Storm\n",
+			want: Table{"SPELL_NAME_1": "Fireball", "SPELL_NAME_12": "Bless", "SPELL_NAME_123": "Meteor Storm"},
+		},
+		{
+			name: "every key kind",
+			in:   "[SPELL_NAME_12] Bless\n[SPELL_DESC_12] Blesses a unit.\n[SPELL_NAME_12_PLURAL] Blesses\n",
+			want: Table{"SPELL_NAME_12": "Bless", "SPELL_DESC_12": "Blesses a unit.", "SPELL_NAME_12_PLURAL": "Blesses"},
+		},
+		{
+			name: "bom and crlf",
+			in:   "\ufeff[SPELL_NAME_1] Fireball\r\n[SPELL_NAME_2] Heal\r\n",
+			want: Table{"SPELL_NAME_1": "Fireball", "SPELL_NAME_2": "Heal"},
+		},
+		{
+			name: "spacing, blanks and comments",
+			in:   "  [ SPELL_NAME_1 ]    Fireball  \n\n// comment\n; comment\n[SPELL_NAME_2]\tHeal\n[EMPTY]\n",
+			want: Table{"SPELL_NAME_1": "Fireball", "SPELL_NAME_2": "Heal", "EMPTY": ""},
+		},
+		{
+			name:    "malformed lines",
+			in:      "[SPELL_NAME_1] Fireball\nSPELL_NAME_2 Heal\n[SPELL_NAME_3 Bless\n[] Nothing\n[SPELL NAME] Spaced\n[SPELL_NAME_4] Haste\n",
+			want:    Table{"SPELL_NAME_1": "Fireball", "SPELL_NAME_4": "Haste"},
+			errLine: []int{2, 3, 4, 5},
+		},
+		{
+			name:    "duplicate keys keep the first",
+			in:      "[SPELL_NAME_1] Fireball\n[SPELL_NAME_1] Firebolt\n",
+			want:    Table{"SPELL_NAME_1": "Fireball"},
+			errLine: []int{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.in))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %v, want %v", got, tt.want)
+			}
+			if len(tt.errLine) == 0 {
+				if err != nil {
+					t.Fatalf("Parse: %v", err)
+				}
+				return
+			}
+			var lineErrs LineErrors
+			if !errors.As(err, &lineErrs) {
+				t.Fatalf("Parse error = %v, want LineErrors", err)
+			}
+			lines := []int{}
+			for _, lineErr := range lineErrs {
+				lines = append(lines, lineErr.Line)
+			}
+			if !reflect.DeepEqual(lines, tt.errLine) {
+				t.Errorf("error lines = %v, want %v", lines, tt.errLine)
+			}
+		})
+	}
+}
+
+func TestPrefixed(t *testing.T) {
+	table := Table{"SPELL_NAME_1": "Fireball", "SPELL_NAME_123": "Meteor Storm", "SPELL_DESC_1": "Burns"}
+	want := map[string]string{"1": "Fireball", "123": "Meteor Storm"}
+	got := table.Prefixed("SPELL_NAME_")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Prefixed = %v, want %v", got, want)
+	}
+}
+
+// encodeUTF16 returns text as UTF-16 with a byte order mark
+func encodeUTF16(text string, bigEndian bool) string {
+	out := []byte{0xFF, 0xFE}
+	if bigEndian {
+		out = []byte{0xFE, 0xFF}
+	}
+	for _, unit := range utf16.Encode([]rune(text)) {
+		if bigEndian {
+			out = append(out, byte(unit>>8), byte(unit))
+			continue
+		}
+		out = append(out, byte(unit), byte(unit>>8))
+	}
+	return string(out)
+}
+
+func TestParseEncodings(t *testing.T) {
+	table := "[SPELL_NAME_1] Feuerbäll\r\n[SPELL_NAME_2] Ωmega 𝄞\r\n"
+	want := Table{"SPELL_NAME_1": "Feuerbäll", "SPELL_NAME_2": "Ωmega 𝄞"}
+	tests := []struct {
+		name    string
+		in      string
+		want    Table
+		wantErr string
+	}{
+		{"utf-8", table, want, ""},
+		{"utf-16 little endian", encodeUTF16(table, false), want, ""},
+		{"utf-16 big endian", encodeUTF16(table, true), want, ""},
+		{"utf-16 cut short", encodeUTF16(table, false)[:9], nil, "odd number of bytes"},
+		{"windows-1252", "[SPELL_NAME_1] Fireball\n[SPELL_NAME_2] Feuerb\xe4ll\n", nil, "line 2: byte 0xE4: not UTF-8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(strings.NewReader(tt.in))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := Parse(strings.NewReader("[A] \xe4"))
+	if !errors.Is(err, ErrNotUTF8) {
+		t.Errorf("Parse of Windows-1252 = %v, want ErrNotUTF8", err)
+	}
+}